@@ -34,6 +34,32 @@ func GetMap(client *gh.Client) Map {
 	}
 }
 
+// IsRemote returns true if the action changes the notification on GitHub.
+func IsRemote(name string) bool {
+	switch name {
+	case "read", "done", "assign":
+		return true
+	default:
+		return false
+	}
+}
+
 type Runner interface {
 	Run(n *notifications.Notification, params []string, out io.Writer) error
 }
+
+// ArgsValidator is implemented by the Runners that check their arguments
+// before running.
+type ArgsValidator interface {
+	ValidateArgs(args []string) error
+}
+
+// ValidateArgs checks the arguments of the named action, if it supports it.
+func (m Map) ValidateArgs(name string, args []string) error {
+	validator, ok := m[name].(ArgsValidator)
+	if !ok {
+		return nil
+	}
+
+	return validator.ValidateArgs(args)
+}
@@ -11,6 +11,7 @@ Usage in the config:
 	rules:
 	  - action: assign
 	    args: [user0, user1]
+	    confirm: true
 
 Usage in the REPL:
 
@@ -44,7 +45,26 @@ type Body struct {
 	Assignees []string `json:"assignees"`
 }
 
-var errNoAssignees = errors.New("no assignees provided")
+var (
+	errNoAssignees      = errors.New("no assignees provided")
+	errInvalidAssignees = errors.New("invalid assignee")
+)
+
+// ValidateArgs checks that there is at least one assignee and that they are
+// not empty.
+func (*Runner) ValidateArgs(assignees []string) error {
+	if len(assignees) == 0 {
+		return errNoAssignees
+	}
+
+	for _, assignee := range assignees {
+		if strings.TrimSpace(assignee) == "" || strings.ContainsAny(assignee, " \t") {
+			return fmt.Errorf("%w: %q", errInvalidAssignees, assignee)
+		}
+	}
+
+	return nil
+}
 
 func (a *Runner) Run(n *notifications.Notification, assignees []string, w io.Writer) error {
 	slog.Debug("assigning notification", "notification", n, "assignees", assignees)
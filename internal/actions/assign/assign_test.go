@@ -175,3 +175,27 @@ func TestIsIssueOrPull(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want error
+	}{
+		{args: []string{"user0", "user1"}},
+		{args: nil, want: errNoAssignees},
+		{args: []string{"user0", ""}, want: errInvalidAssignees},
+		{args: []string{"user0 user1"}, want: errInvalidAssignees},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, ","), func(t *testing.T) {
+			t.Parallel()
+
+			if err := (&Runner{}).ValidateArgs(test.args); !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
@@ -24,10 +24,12 @@ Usage in the REPL:
 package tag
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"slices"
+	"strings"
 
 	"github.com/nobe4/gh-not/internal/colors"
 	"github.com/nobe4/gh-not/internal/notifications"
@@ -35,6 +37,28 @@ import (
 
 type Runner struct{}
 
+var (
+	errNoTags     = errors.New("no tags provided")
+	errInvalidTag = errors.New("invalid tag")
+)
+
+// ValidateArgs checks that there is at least one tag and that each tag has a
+// name after its optional `+` or `-` prefix.
+func (*Runner) ValidateArgs(tags []string) error {
+	if len(tags) == 0 {
+		return errNoTags
+	}
+
+	for _, tag := range tags {
+		name := strings.TrimLeft(tag, "+-")
+		if len(tag)-len(name) > 1 || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: %q", errInvalidTag, tag)
+		}
+	}
+
+	return nil
+}
+
 func (*Runner) Run(n *notifications.Notification, tags []string, w io.Writer) error {
 	slog.Debug("tagging notification", "notification", n.ID, "tags", tags)
 
@@ -1,6 +1,7 @@
 package tag
 
 import (
+	"errors"
 	"slices"
 	"strings"
 	"testing"
@@ -71,3 +72,28 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want error
+	}{
+		{args: []string{"tag0", "+tag1", "-tag2"}},
+		{args: nil, want: errNoTags},
+		{args: []string{"+"}, want: errInvalidTag},
+		{args: []string{"tag0", ""}, want: errInvalidTag},
+		{args: []string{"+-tag0"}, want: errInvalidTag},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			t.Parallel()
+
+			if err := (&Runner{}).ValidateArgs(test.args); !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 	gh "github.com/cli/go-gh/v2/pkg/api"
 )
 
-func New() (*gh.RESTClient, error) {
-	client, err := gh.DefaultRESTClient()
+// New creates a REST client for the host.
+// An empty host uses `gh`'s default host.
+func New(host string) (*gh.RESTClient, error) {
+	client, err := gh.NewRESTClient(gh.ClientOptions{Host: host})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
@@ -11,14 +11,17 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	configpkg "github.com/nobe4/gh-not/internal/config"
 )
 
 //nolint:gochecknoglobals // This is how cobra is used.
 var (
-	editConfigFlag = false
-	initConfigFlag = false
+	editConfigFlag     = false
+	initConfigFlag     = false
+	validateConfigFlag = false
+	strictConfigFlag   = true
 
 	configCmd = &cobra.Command{
 		Use:   "config",
@@ -35,6 +38,10 @@ func init() {
 
 	configCmd.Flags().BoolVarP(&editConfigFlag, "edit", "e", false, "Edit the config in $EDITOR")
 	configCmd.Flags().BoolVarP(&initConfigFlag, "init", "i", false, "Create the default config file")
+	configCmd.Flags().BoolVarP(&validateConfigFlag, "validate", "", false, "Validate the config and exit")
+	configCmd.Flags().BoolVarP(&strictConfigFlag, "strict", "", true,
+		"With --validate, fail on unknown keys, invalid action arguments and keymap errors")
+	configCmd.MarkFlagsMutuallyExclusive("init", "edit", "validate")
 }
 
 func runConfig(c *cobra.Command, _ []string) error {
@@ -52,17 +59,80 @@ func runConfig(c *cobra.Command, _ []string) error {
 		return editConfig()
 	}
 
+	if validateConfigFlag {
+		if err := validateConfig(); err != nil {
+			c.SilenceUsage = true
+
+			return err
+		}
+
+		return nil
+	}
+
 	marshaled, err := config.Marshal()
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	if marshaled, err = annotateOrigins(marshaled, config.Data.Rules); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
 	//nolint:forbidigo // This is an expected print statement.
 	fmt.Printf("Config sourced from: %s\n\n%s\n", config.Path, marshaled)
 
 	return nil
 }
 
+// annotateOrigins adds a comment above each rule with the file declaring it,
+// as the included files and the profiles can declare rules.
+func annotateOrigins(marshaled []byte, rules []configpkg.Rule) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(marshaled, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse the config: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return marshaled, nil
+	}
+
+	root := document.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "rules" {
+			continue
+		}
+
+		for j, rule := range root.Content[i+1].Content {
+			if j < len(rules) && rules[j].Origin.File != "" {
+				rule.HeadComment = "from " + rules[j].Origin.String()
+			}
+		}
+	}
+
+	annotated, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the config: %w", err)
+	}
+
+	return annotated, nil
+}
+
+// validateConfig runs the strict validation, the normal validation already ran
+// when loading the config.
+func validateConfig() error {
+	if strictConfigFlag {
+		if err := config.ValidateStrict(); err != nil {
+			return fmt.Errorf("failed to validate the config %s: %w", config.Path, err)
+		}
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Printf("Config %s is valid\n", config.Path)
+
+	return nil
+}
+
 func initConfig() error {
 	slog.Debug("creating initial config file", "path", configPathFlag)
 
@@ -77,6 +147,8 @@ func initConfig() error {
 		return fmt.Errorf("%w: %s", errConfigExists, initialConfig.ConfigFileUsed())
 	}
 
+	initialConfig.Set("version", configpkg.CurrentVersion)
+
 	if err := initialConfig.WriteConfig(); err != nil {
 		return fmt.Errorf("failed to save initial config: %w", err)
 	}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	configpkg "github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/logger"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var (
+	configMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the config file to the current version",
+		Long: `
+'gh-not config migrate' rewrites a config file from an older version to the
+current one, e.g. confirming the rules that change GitHub. The included files
+are migrated too.
+
+It saves a backup of each changed file next to it before writing the new one,
+and prints the changes.
+`,
+		Example: `
+  gh-not config migrate
+  gh-not config migrate --config /path/to/config.yaml
+`,
+		Args: cobra.NoArgs,
+		// The config can't be loaded before it's migrated.
+		PersistentPreRun: func(_ *cobra.Command, _ []string) {
+			logger.Init(verbosityFlag)
+		},
+		RunE: runConfigMigrate,
+	}
+
+	errBackupExists = errors.New("backup file already exists; delete it if you want it overwritten")
+)
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func runConfigMigrate(c *cobra.Command, _ []string) error {
+	c.SilenceUsage = true
+
+	path, err := configpkg.ExpandPathWithoutTilde(configPathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to expand config path: %w", err)
+	}
+
+	_, path = configpkg.Default(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file: %w", err)
+	}
+
+	migration, err := configpkg.Migrate(content)
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	if !migration.Changed() {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Printf("Config %s is already in version %d\n", path, migration.To)
+
+		return nil
+	}
+
+	// Migrate the included files before writing anything, so that a failure
+	// doesn't leave the config half migrated.
+	migrations, paths, err := migrateIncludes(migration)
+	if err != nil {
+		return err
+	}
+
+	migrations = append([]configpkg.Migration{migration}, migrations...)
+	paths = append([]string{path}, paths...)
+
+	for i, migration := range migrations {
+		if _, err := os.Stat(backupPath(paths[i], migration)); !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", errBackupExists, backupPath(paths[i], migration))
+		}
+	}
+
+	for i, migration := range migrations {
+		if err := writeMigration(paths[i], migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateIncludes migrates the files included by the config, from the config's
+// version. It returns the changed files only.
+func migrateIncludes(migration configpkg.Migration) ([]configpkg.Migration, []string, error) {
+	files, err := configpkg.IncludeFiles(migration.Includes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the included files: %w", err)
+	}
+
+	migrations := []configpkg.Migration{}
+	paths := []string{}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the included file: %w", err)
+		}
+
+		included, err := configpkg.MigrateInclude(content, migration.From)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate %s: %w", file, err)
+		}
+
+		if included.Changed() {
+			migrations = append(migrations, included)
+			paths = append(paths, file)
+		}
+	}
+
+	return migrations, paths, nil
+}
+
+// writeMigration saves a backup of the file, writes the migrated content and
+// prints the changes.
+func writeMigration(path string, migration configpkg.Migration) error {
+	backupPath := backupPath(path, migration)
+
+	if err := os.WriteFile(backupPath, migration.Before, 0o600); err != nil {
+		return fmt.Errorf("failed to write the backup: %w", err)
+	}
+
+	if err := os.WriteFile(path, migration.After, 0o600); err != nil {
+		return fmt.Errorf("failed to write the migrated config: %w", err)
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Printf("Migrated %s from version %d to %d, backup saved to %s\n\n%s\n",
+		path, migration.From, migration.To, backupPath, migration.Diff())
+
+	return nil
+}
+
+func backupPath(path string, migration configpkg.Migration) string {
+	return fmt.Sprintf("%s.v%d.bak", path, migration.From)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	configpkg "github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/logger"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the config file",
+	Long: `
+'gh-not config schema' prints the JSON Schema of the config file.
+
+Use it with an editor's YAML plugin to get completion and validation, e.g. with
+yaml-language-server, add at the top of the config file:
+
+  # yaml-language-server: $schema=/path/to/gh-not.schema.json
+`,
+	Example: `
+  gh-not config schema > gh-not.schema.json
+`,
+	Args: cobra.NoArgs,
+	// The schema doesn't depend on the config, which might be invalid.
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		logger.Init(verbosityFlag)
+	},
+	RunE: runConfigSchema,
+}
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func runConfigSchema(_ *cobra.Command, _ []string) error {
+	marshaled, err := json.MarshalIndent(configpkg.Schema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the schema: %w", err)
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Printf("%s\n", marshaled)
+
+	return nil
+}
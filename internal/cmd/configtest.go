@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var (
+	configTestCmd = &cobra.Command{
+		Use:   "test",
+		Short: "Run the tests declared in the rules",
+		Long: `
+'gh-not config test' runs each rule's filters against the sample notifications
+declared in its 'tests' and compares the result with the expected 'match'.
+
+It exits with a non-zero status if any test fails, which makes it usable in CI.
+`,
+		Example: `
+  gh-not config test
+  gh-not config test --config /path/to/config.yaml
+`,
+		Args: cobra.NoArgs,
+		RunE: runConfigTest,
+	}
+
+	errRuleTestsFailed = errors.New("rule tests failed")
+)
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	configCmd.AddCommand(configTestCmd)
+}
+
+func runConfigTest(c *cobra.Command, _ []string) error {
+	count, failures := config.TestRules()
+
+	for _, failure := range failures {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Printf("FAIL %s\n", failure)
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Printf("%d tests, %d failures\n", count, len(failures))
+
+	if len(failures) > 0 {
+		c.SilenceUsage = true
+
+		return fmt.Errorf("%w: %d/%d", errRuleTestsFailed, len(failures), count)
+	}
+
+	return nil
+}
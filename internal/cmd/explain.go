@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nobe4/gh-not/internal/colors"
+	configpkg "github.com/nobe4/gh-not/internal/config"
+	managerpkg "github.com/nobe4/gh-not/internal/manager"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var (
+	explainCmd = &cobra.Command{
+		Use:   "explain <notification-id|url|owner/repo#number>",
+		Short: "Explain how the rules apply to a notification",
+		Long: `
+'gh-not explain' evaluates every rule against a single notification from the
+cache, without running any action.
+
+It shows the result of each filter and which actions would run.
+`,
+		Example: `
+  gh-not explain 123456789
+  gh-not explain https://github.com/nobe4/gh-not/pull/1
+  gh-not explain nobe4/gh-not#1
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExplain,
+	}
+
+	errNotificationNotFound = errors.New("notification not found")
+)
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+func runExplain(c *cobra.Command, args []string) error {
+	if err := manager.Load(); err != nil {
+		return fmt.Errorf("failed to load the notifications: %w", err)
+	}
+
+	trySetGitHubCaller()
+
+	n := manager.Notifications.Find(args[0])
+	if n == nil {
+		c.SilenceUsage = true
+
+		return fmt.Errorf("%w: %s", errNotificationNotFound, args[0])
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Printf("%s\n\n", n)
+
+	for _, t := range manager.Explain(n) {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Println(formatTrace(t))
+	}
+
+	return nil
+}
+
+func formatTrace(t managerpkg.Trace) string {
+	origin := t.Rule.Origin.String()
+	if t.Rule.Origin.File == "" {
+		origin = fmt.Sprintf("index %d", t.Index)
+	}
+
+	out := []string{
+		fmt.Sprintf("Rule %q (%s, priority %d)", t.Rule.Name, origin, t.Rule.Priority),
+	}
+
+	for _, f := range t.Filters {
+		switch {
+		case f.Err != nil:
+			out = append(out, fmt.Sprintf("  %s %s: %v", colors.Yellow("!"), f.Filter, f.Err))
+		case f.Matched:
+			out = append(out, fmt.Sprintf("  %s %s", colors.Green("✓"), f.Filter))
+		default:
+			out = append(out, fmt.Sprintf("  %s %s", colors.Red("✗"), f.Filter))
+		}
+	}
+
+	switch {
+	case t.Skipped != "" && t.Matched:
+		out = append(out, "  → match, skipped: "+t.Skipped)
+
+	case t.Skipped != "":
+		out = append(out, "  → no match, skipped: "+t.Skipped)
+
+	case !t.Matched:
+		out = append(out, "  → no match")
+
+	default:
+		out = append(out, "  → match, would run: "+formatActions(t.Rule.ActionList()))
+
+		if !t.Rule.Continues() {
+			out = append(out, "  → stops the following rules")
+		}
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+func formatActions(actions []configpkg.RuleAction) string {
+	out := make([]string, 0, len(actions))
+
+	for _, a := range actions {
+		out = append(out, strings.TrimSpace(a.Name+" "+strings.Join(a.Args, " ")))
+	}
+
+	return strings.Join(out, ", ")
+}
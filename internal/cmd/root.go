@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/text"
@@ -23,12 +26,19 @@ import (
 var (
 	verbosityFlag  int
 	configPathFlag string
+	profileFlag    string
+	setFlag        []string
 	ruleFlag       string
 	filterFlag     string
 	tagFlag        string
 	tagsFlag       bool
 	replFlag       bool
 	jsonFlag       bool
+	formatFlag     string
+	outputFlag     notifications.Output
+	sortFlag       string
+	limitFlag      int
+	groupByFlag    string
 	allFlag        bool
 
 	config  *configpkg.Config
@@ -41,9 +51,16 @@ var (
 		Example: `
   gh-not --verbosity 2
   gh-not --config /path/to/config.yaml
+  gh-not --profile work
+  gh-not --set endpoint.max_page=20 --set view.height=10
+  GHNOT_ENDPOINT_MAX_PAGE=20 gh-not sync
   gh-not --filter '(.repository.full_name | contains("nobe4")) or (.subject.title | contains("CI"))'
   gh-not --tag tag0
+  gh-not --sort repo:asc --limit 10
+  gh-not --sort '.subject.title | ascii_downcase' --group-by reason
   gh-not --json --all --rule 'ignore CI'
+  gh-not --format '{{.Repository.FullName}}#{{.Number}} {{.Subject.Title | truncate 40}}'
+  gh-not --output markdown --filter '.reason == "review_requested"'
   gh-not --repl  // will log in the file /tmp/gh-not-debug.log
 `,
 		PersistentPreRunE: setupGlobals,
@@ -69,6 +86,8 @@ func init() {
 
 	rootCmd.PersistentFlags().IntVarP(&verbosityFlag, "verbosity", "v", 1, "Change logger verbosity")
 	rootCmd.PersistentFlags().StringVarP(&configPathFlag, "config", "c", "", "Path to the YAML config file")
+	rootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "", "", "Name of the config profile to use")
+	rootCmd.PersistentFlags().StringArrayVarP(&setFlag, "set", "", nil, "Override a config key, e.g. 'view.height=10'")
 
 	// Filter
 	rootCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "List all the notifications, even the hidden/done ones.")
@@ -77,11 +96,21 @@ func init() {
 	rootCmd.Flags().StringVarP(&tagFlag, "tag", "t", "", "Filter from a single tag")
 	rootCmd.MarkFlagsMutuallyExclusive("rule", "filter", "tag")
 
+	// Order
+	rootCmd.Flags().StringVarP(&sortFlag, "sort", "", "updated:desc",
+		"Sort by a key, one of "+strings.Join(notifications.SortKeyNames(), ", ")+
+			", or a jq expression, optionally followed by ':asc' or ':desc'")
+	rootCmd.Flags().IntVarP(&limitFlag, "limit", "", 0, "Show at most this many notifications, 0 means no limit")
+	rootCmd.Flags().StringVarP(&groupByFlag, "group-by", "", "",
+		"Group by a key, one of "+strings.Join(notifications.GroupKeyNames(), ", "))
+
 	// Display
 	rootCmd.Flags().BoolVarP(&jsonFlag, "json", "j", false, "Output the selected notifications as JSON")
 	rootCmd.Flags().BoolVarP(&tagsFlag, "tags", "", false, "Show the list of tags with associated notification count")
 	rootCmd.Flags().BoolVarP(&replFlag, "repl", "", false, "Start a REPL with the notifications list")
-	rootCmd.MarkFlagsMutuallyExclusive("json", "repl", "tags")
+	rootCmd.Flags().StringVarP(&formatFlag, "format", "", "", "Output each notification with a Go template, or a template name from the config")
+	rootCmd.Flags().VarP(&outputFlag, "output", "o", "Output format, one of "+outputFlag.Allowed())
+	rootCmd.MarkFlagsMutuallyExclusive("json", "repl", "tags", "format", "output")
 }
 
 func setupGlobals(c *cobra.Command, _ []string) error {
@@ -90,18 +119,25 @@ func setupGlobals(c *cobra.Command, _ []string) error {
 	slog.Debug("flags",
 		"verbosity", verbosityFlag,
 		"config", configPathFlag,
+		"profile", profileFlag,
+		"set", setFlag,
 		"all", allFlag,
 		"rule", ruleFlag,
 		"filter", filterFlag,
 		"tag", tagFlag,
+		"sort", sortFlag,
+		"limit", limitFlag,
+		"group-by", groupByFlag,
 		"tags", tagsFlag,
 		"json", jsonFlag,
+		"format", formatFlag,
+		"output", outputFlag.String(),
 		"repl", replFlag,
 	)
 
 	var err error
 
-	config, err = configpkg.New(configPathFlag)
+	config, err = configpkg.New(configPathFlag, profileFlag, setFlag)
 	if err != nil {
 		c.SilenceUsage = true
 
@@ -118,16 +154,14 @@ func runRoot(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to load the notifications: %w", err)
 	}
 
-	n := load()
+	trySetGitHubCaller()
 
-	n, err := filter(n)
+	n, groups, err := selectNotifications(manager)
 	if err != nil {
-		slog.Error("Failed to filter the notifications", "err", err)
-
 		return err
 	}
 
-	if err := display(n); err != nil {
+	if err := display(n, groups); err != nil {
 		slog.Error("Failed to display the notifications", "err", err)
 
 		return err
@@ -136,20 +170,90 @@ func runRoot(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func load() notifications.Notifications {
+// trySetGitHubCaller sets the GitHub API client on the manager, so that the
+// filters can use the jq functions that need it, e.g. `is_mine`.
+// It's not an error to have no client, e.g. without authentication or network:
+// only the filters calling those functions fail.
+func trySetGitHubCaller() {
+	caller, err := github.New(config.Data.Host)
+	if err != nil {
+		slog.Debug("failed to create an API REST client", "err", err)
+
+		return
+	}
+
+	manager.SetCaller(caller)
+}
+
+// selectNotifications returns the manager's notifications to display,
+// filtered, sorted and grouped with the flags. The groups are nil without
+// `--group-by`, otherwise the notifications are listed group by group.
+func selectNotifications(m *managerpkg.Manager) (notifications.Notifications, notifications.Groups, error) {
+	n, err := filter(load(m))
+	if err != nil {
+		slog.Error("Failed to filter the notifications", "err", err)
+
+		return nil, nil, err
+	}
+
+	n, err = order(n)
+	if err != nil {
+		slog.Error("Failed to sort the notifications", "err", err)
+
+		return nil, nil, err
+	}
+
+	if groupByFlag == "" {
+		return n, nil, nil
+	}
+
+	groups, err := n.GroupBy(groupByFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid group-by: %w", err)
+	}
+
+	return groups.Flatten(), groups, nil
+}
+
+func load(m *managerpkg.Manager) notifications.Notifications {
 	var n notifications.Notifications
 
 	if allFlag {
-		n = manager.Notifications
+		n = m.Notifications
 	} else {
-		n = manager.Notifications.Visible()
+		n = m.Notifications.Visible()
 	}
 
-	n.Sort()
-
 	return n
 }
 
+// order sorts the notifications with `--sort` and keeps the first `--limit`.
+// The sort key is either a built-in key or a jq expression, with an optional
+// direction, ascending by default.
+func order(n notifications.Notifications) (notifications.Notifications, error) {
+	// Don't sort the manager's notifications in place.
+	n = slices.Clone(n)
+
+	key, desc := sortFlag, false
+	if k, ok := strings.CutSuffix(key, ":desc"); ok {
+		key, desc = k, true
+	} else if k, ok := strings.CutSuffix(key, ":asc"); ok {
+		key = k
+	}
+
+	if compare, ok := notifications.SortCompare(key); ok {
+		n.SortBy(compare, desc)
+	} else if err := jq.SortBy(key, n, desc); err != nil {
+		return nil, fmt.Errorf("invalid sort '%s': %w", sortFlag, err)
+	}
+
+	if limitFlag > 0 && len(n) > limitFlag {
+		n = n[:limitFlag]
+	}
+
+	return n, nil
+}
+
 //revive:disable:cognitive-complexity // TODO: simplify.
 func filter(n notifications.Notifications) (notifications.Notifications, error) {
 	var err error
@@ -191,7 +295,10 @@ func filter(n notifications.Notifications) (notifications.Notifications, error)
 	return n, nil
 }
 
-func display(n notifications.Notifications) error {
+// display shows the notifications. With `--group-by`, the table renders a
+// section per group while the other outputs list the notifications group by
+// group.
+func display(n notifications.Notifications, groups notifications.Groups) error {
 	if tagsFlag {
 		return displayTags(n)
 	}
@@ -200,22 +307,62 @@ func display(n notifications.Notifications) error {
 		return displayJSON(n)
 	}
 
-	if err := n.Render(); err != nil {
-		slog.Warn("Failed to generate a table, using toString", "err", err)
+	if formatFlag != "" {
+		return displayFormat(n)
+	}
+
+	if outputFlag != notifications.OutputTable {
+		columns, err := config.Data.View.RenderColumns()
+		if err != nil {
+			return fmt.Errorf("failed to get the columns: %w", err)
+		}
+
+		return displayOutput(n, columns)
+	}
+
+	header, err := render(n)
+	if err != nil {
+		return err
 	}
 
 	if replFlag {
 		return displayRepl(n)
 	}
 
-	displayTable(n)
+	displayTable(n, groups, header)
 
 	return nil
 }
 
-func displayTable(n notifications.Notifications) {
-	//nolint:forbidigo // This is an expected print statement.
-	fmt.Println(n)
+// render renders the notifications' lines with the configured columns, and
+// returns the table's header.
+func render(n notifications.Notifications) (string, error) {
+	columns, err := config.Data.View.RenderColumns()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the columns: %w", err)
+	}
+
+	header, err := n.Render(columns)
+	if err != nil {
+		slog.Warn("Failed to generate a table, using toString", "err", err)
+	}
+
+	return header, nil
+}
+
+func displayTable(n notifications.Notifications, groups notifications.Groups, header string) {
+	if header != "" {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Println(header)
+	}
+
+	if groups != nil {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Println(groups)
+	} else {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Println(n)
+	}
 
 	//nolint:forbidigo // This is an expected print statement.
 	fmt.Printf("Found %d notifications %s\n",
@@ -239,6 +386,34 @@ func displayJSON(n notifications.Notifications) error {
 	return nil
 }
 
+func displayOutput(n notifications.Notifications, columns []notifications.Column) error {
+	if err := n.Write(os.Stdout, outputFlag, columns); err != nil {
+		return fmt.Errorf("failed to write the notifications as %s: %w", outputFlag.String(), err)
+	}
+
+	return nil
+}
+
+// displayFormat uses the named template from the config if it exists, or the
+// flag's value as the template.
+func displayFormat(n notifications.Notifications) error {
+	text, ok := config.Data.View.Templates[formatFlag]
+	if !ok {
+		text = formatFlag
+	}
+
+	t, err := notifications.NewTemplate(text)
+	if err != nil {
+		return fmt.Errorf("invalid format: %w", err)
+	}
+
+	if err := n.Format(os.Stdout, t); err != nil {
+		return fmt.Errorf("failed to format the notifications: %w", err)
+	}
+
+	return nil
+}
+
 func displayTags(n notifications.Notifications) error {
 	for tag, count := range n.TagsMap() {
 		//nolint:forbidigo // This is an expected print statement.
@@ -249,7 +424,7 @@ func displayTags(n notifications.Notifications) error {
 }
 
 func displayRepl(n notifications.Notifications) error {
-	caller, err := github.New()
+	caller, err := github.New(config.Data.Host)
 	if err != nil {
 		return fmt.Errorf("failed to create an API REST client: %w", err)
 	}
@@ -267,7 +442,11 @@ func displayRepl(n notifications.Notifications) error {
 	}
 	defer f.Close()
 
-	if err := repl.Init(n, manager.Actions, config.Data.Keymap, config.Data.View); err != nil {
+	// The actions and the refreshes also write there.
+	manager.SetOutput(f)
+
+	err = repl.Init(n, manager.Actions, config.Data.Rules, config.Data.Keymap, config.Data.View, refreshRepl, manager.Now)
+	if err != nil {
 		return fmt.Errorf("failed to init the REPL: %w", err)
 	}
 
@@ -277,3 +456,42 @@ func displayRepl(n notifications.Notifications) error {
 
 	return nil
 }
+
+// refreshRepl syncs a copy of the notifications like `gh-not sync` and saves
+// it, as the REPL keeps listing the current ones meanwhile. It returns the
+// notifications to list, selected with the same flags as when the REPL
+// started, and a function to keep the copy once the REPL lists them.
+// If a rule failed, it still returns the saved copy along with the error.
+func refreshRepl() (notifications.Notifications, func(), error) {
+	refreshed := manager.Copy()
+
+	if err := refreshed.RefreshWith(managerpkg.ForceRefresh); err != nil {
+		return nil, nil, fmt.Errorf("failed to refresh the notifications: %w", err)
+	}
+
+	applyErr := refreshed.Apply()
+
+	// Save the actions already run, even if a rule failed.
+	if err := refreshed.Save(); err != nil {
+		return nil, nil, fmt.Errorf("failed to save the notifications: %w", err)
+	}
+
+	n, _, err := selectNotifications(refreshed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := render(n); err != nil {
+		return nil, nil, err
+	}
+
+	keep := func() {
+		manager.Notifications = refreshed.Notifications
+	}
+
+	if applyErr != nil {
+		return n, keep, fmt.Errorf("failed to apply the rules: %w", applyErr)
+	}
+
+	return n, keep, nil
+}
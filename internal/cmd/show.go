@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var showCmd = &cobra.Command{
+	Use:   "show <notification-id|url|owner/repo#number>",
+	Short: "Show a notification's details and history",
+	Long: `
+'gh-not show' shows everything about a single notification from the cache: its
+subject, state, reason, people, tags, status, URLs and latest comment, and its
+history: when it was first seen, its remote updates, and the actions run on it.
+
+It uses the enrichment data already in the cache and doesn't call GitHub.
+The history is also available to jq as '.meta.history'.
+`,
+	Example: `
+  gh-not show 123456789
+  gh-not show https://github.com/nobe4/gh-not/pull/1
+  gh-not show nobe4/gh-not#1
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	rootCmd.AddCommand(showCmd)
+}
+
+func runShow(c *cobra.Command, args []string) error {
+	if err := manager.Load(); err != nil {
+		return fmt.Errorf("failed to load the notifications: %w", err)
+	}
+
+	n := manager.Notifications.Find(args[0])
+	if n == nil {
+		c.SilenceUsage = true
+
+		return fmt.Errorf("%w: %s", errNotificationNotFound, args[0])
+	}
+
+	//nolint:forbidigo // This is an expected print statement.
+	fmt.Println(n.Card())
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // This is how cobra is used.
+var (
+	statsJSONFlag bool
+
+	statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Show statistics about the notifications",
+		Long: `
+'gh-not stats' counts all the notifications in the cache, including the done
+and hidden ones.
+
+It shows the counts by reason, repository, subject type, state, author, tag and
+age, and how many notifications are unread, done, hidden and unenriched.
+`,
+		Example: `
+  gh-not stats
+  gh-not stats --json >> stats.ndjson
+`,
+		Args: cobra.NoArgs,
+		RunE: runStats,
+	}
+)
+
+//nolint:gochecknoinits // TODO: check if this can be changed.
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVarP(&statsJSONFlag, "json", "j", false, "Output the statistics as JSON, on a single line")
+}
+
+func runStats(_ *cobra.Command, _ []string) error {
+	if err := manager.Load(); err != nil {
+		return fmt.Errorf("failed to load the notifications: %w", err)
+	}
+
+	stats := manager.Notifications.Compact().Stats(time.Now())
+
+	if !statsJSONFlag {
+		//nolint:forbidigo // This is an expected print statement.
+		fmt.Print(stats)
+
+		return nil
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(stats); err != nil {
+		return fmt.Errorf("failed to marshal the statistics: %w", err)
+	}
+
+	return nil
+}
@@ -61,7 +61,7 @@ func runSync(_ *cobra.Command, _ []string) error {
 	if notificationDumpPath != "" {
 		caller = file.New(notificationDumpPath)
 	} else {
-		caller, err = github.New()
+		caller, err = github.New(config.Data.Host)
 		if err != nil {
 			return fmt.Errorf("failed to create an API REST client: %w", err)
 		}
@@ -85,6 +85,11 @@ func runSync(_ *cobra.Command, _ []string) error {
 	refreshedNotifications := len(manager.Notifications)
 
 	if err := manager.Apply(); err != nil {
+		// Save the actions already run before aborting.
+		if saveErr := manager.Save(); saveErr != nil {
+			return fmt.Errorf("failed to save the notifications: %w", saveErr)
+		}
+
 		return fmt.Errorf("failed to apply the rules: %w", err)
 	}
 
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+
+	"github.com/nobe4/gh-not/internal/jq"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+var errInvalidColumn = errors.New("invalid column")
+
+// Column is a column of the notifications list, used by the table and the
+// REPL. It's either a built-in column, or a jq filter.
+// A built-in column can be written as its name only.
+//
+//	view:
+//	  columns:
+//	    - read
+//	    - repo
+//	    - name: title
+//	      width: 50
+//	    - filter: .meta.tags | join(",")
+//	      header: tags
+//	      color: "5"
+type Column struct {
+	// Name is the name of a built-in column.
+	// See notifications.BuiltinColumnNames.
+	Name string `mapstructure:"name" yaml:"name,omitempty"`
+
+	// Filter is a jq expression run on the notification.
+	Filter string `mapstructure:"filter" yaml:"filter,omitempty"`
+
+	// Header is the column's title.
+	Header string `mapstructure:"header" yaml:"header,omitempty"`
+
+	// Width is the maximum width of the column, 0 means no limit.
+	Width int `mapstructure:"width" yaml:"width,omitempty"`
+
+	// Color is the color of the column, e.g. `1` or `#ff0000`.
+	Color string `mapstructure:"color" yaml:"color,omitempty"`
+}
+
+// RenderColumns returns the columns to render the notifications with, the
+// default ones if none is configured.
+// The jq columns convert each notification once, so get new columns for each
+// render, e.g. after an action changed the notifications.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func (v View) RenderColumns() ([]notifications.Column, error) {
+	// The jq columns share the converted notifications.
+	raw, err := jq.NewRaw(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert notifications: %w", err)
+	}
+
+	columns := make([]notifications.Column, 0, len(v.Columns))
+
+	for i, c := range v.Columns {
+		var column notifications.Column
+
+		switch {
+		case c.Name != "" && c.Filter != "":
+			return nil, fmt.Errorf("%w %d: set either a name or a filter", errInvalidColumn, i)
+
+		case c.Name != "":
+			if column, err = notifications.BuiltinColumn(c.Name); err != nil {
+				return nil, fmt.Errorf("%w %d: %w", errInvalidColumn, i, err)
+			}
+
+		case c.Filter != "":
+			if err := jq.Validate(c.Filter); err != nil {
+				return nil, fmt.Errorf("%w %d: invalid jq filter %s: %w", errInvalidColumn, i, c.Filter, err)
+			}
+
+			column.Name = c.Filter
+			column.Value = filterValue(raw, c.Filter)
+
+		default:
+			return nil, fmt.Errorf("%w %d: set a name or a filter", errInvalidColumn, i)
+		}
+
+		column.Header = c.Header
+		column.Width = c.Width
+		column.Color = c.Color
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func filterValue(raw *jq.Raw, filter string) func(n *notifications.Notification) string {
+	return func(n *notifications.Notification) string {
+		value, err := raw.String(filter, n)
+		if err != nil {
+			slog.Debug("failed to run the column filter", "filter", filter, "id", n.ID, "err", err)
+
+			return "?"
+		}
+
+		return strings.ReplaceAll(value, "\n", " ")
+	}
+}
+
+// decodeHook is viper's default decode hook, with the config's shorthands.
+func decodeHook() viper.DecoderConfigOption {
+	return viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		columnHook,
+	))
+}
+
+// columnHook decodes a column's name into a Column.
+func columnHook(from, to reflect.Type, data any) (any, error) {
+	if to != reflect.TypeFor[Column]() || from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	name, _ := data.(string)
+
+	return Column{Name: name}, nil
+}
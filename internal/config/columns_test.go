@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+func TestRenderColumns(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(writeConfig(t, `
+view:
+  columns:
+    - id
+    - name: reason
+      header: Reason
+    - filter: .meta.tags | join("+")
+      width: 10
+`), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns, err := c.Data.View.RenderColumns()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := &notifications.Notification{ID: "0", Reason: "mention", Meta: notifications.Meta{Tags: []string{"a", "b"}}}
+	want := []string{"0", "mention", "a+b"}
+
+	if len(columns) != len(want) {
+		t.Fatalf("want %d columns, got %d", len(want), len(columns))
+	}
+
+	for i, column := range columns {
+		if got := column.Value(n); got != want[i] {
+			t.Errorf("column %d: want %q, got %q", i, want[i], got)
+		}
+	}
+
+	if columns[1].Header != "Reason" || columns[2].Width != 10 {
+		t.Errorf("want the header and width, got %#v and %#v", columns[1], columns[2])
+	}
+}
+
+func TestRenderColumnsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		columns []Column
+	}{
+		{name: "unknown", columns: []Column{{Name: "nope"}}},
+		{name: "empty", columns: []Column{{Header: "a"}}},
+		{name: "both", columns: []Column{{Name: "id", Filter: ".id"}}},
+		{name: "invalid filter", columns: []Column{{Filter: ".["}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := (View{Columns: test.columns}).RenderColumns(); !errors.Is(err, errInvalidColumn) {
+				t.Errorf("want %v, got %v", errInvalidColumn, err)
+			}
+		})
+	}
+}
@@ -7,10 +7,13 @@ See individual types for more information on the configuration.
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"maps"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -19,53 +22,127 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/nobe4/gh-not/internal/gh"
+	"github.com/nobe4/gh-not/internal/jq"
 )
 
-const validationErrorStr = `Invalid rule (index %d): 
+const validationErrorStr = `Invalid rule (%s): 
 %s
 Errors: 
 %s`
 
-var errRuleValidation = errors.New("invalid rules")
+var (
+	errRuleValidation  = errors.New("invalid rules")
+	errProfileNotFound = errors.New("profile not found")
+)
 
 // Config holds the configuration data.
 type Config struct {
-	viper *viper.Viper
-	Path  string
-	Data  *Data
+	viper   *viper.Viper
+	Path    string
+	Profile string
+	Data    *Data
+
+	// origins holds where each rule is declared, in the same order as the
+	// rules.
+	origins []Origin
+
+	// profileFiles holds the file declaring each profile's rules.
+	profileFiles map[string]string
+
+	// libraryFile is the file declaring `jq.library_path`, if it's included.
+	libraryFile string
 }
 
 // Data holds the configuration data.
 type Data struct {
-	Cache      Cache       `mapstructure:"cache"`
-	Endpoint   gh.Endpoint `mapstructure:"endpoint"`
-	Enrichment Enrichment  `mapstructure:"enrichment"`
-	Keymap     Keymap      `mapstructure:"keymap"`
-	View       View        `mapstructure:"view"`
-	Rules      []Rule      `mapstructure:"rules"`
+	// Version is the version of the configuration format.
+	// See CurrentVersion.
+	Version int `mapstructure:"version" yaml:"version"`
+
+	Apply      Apply       `mapstructure:"apply" yaml:"apply"`
+	Cache      Cache       `mapstructure:"cache" yaml:"cache"`
+	Endpoint   gh.Endpoint `mapstructure:"endpoint" yaml:"endpoint"`
+	Enrichment Enrichment  `mapstructure:"enrichment" yaml:"enrichment"`
+	Host       string      `mapstructure:"host" yaml:"host"`
+	Include    []string    `mapstructure:"include" yaml:"include"`
+	JQ         JQ          `mapstructure:"jq" yaml:"jq"`
+	Keymap     Keymap      `mapstructure:"keymap" yaml:"keymap"`
+	View       View        `mapstructure:"view" yaml:"view"`
+	Rules      []Rule      `mapstructure:"rules" yaml:"rules"`
+
+	Profiles map[string]Profile `mapstructure:"profiles" yaml:"profiles"`
+}
+
+// Profile overrides parts of the configuration when selected with
+// `--profile`. The sections it doesn't set are inherited from the top level.
+//
+//	keymap: ...
+//	profiles:
+//	  work:
+//	    host: github.example.com
+//	    cache:
+//	      path: $HOME/.local/state/gh-not/work.json
+//	    rules: ...
+type Profile struct {
+	Cache    Cache       `mapstructure:"cache" yaml:"cache,omitempty"`
+	Endpoint gh.Endpoint `mapstructure:"endpoint" yaml:"endpoint,omitempty"`
+
+	// Host is the GitHub host to use, e.g. `github.example.com`.
+	// An empty host uses `gh`'s default host.
+	Host string `mapstructure:"host" yaml:"host,omitempty"`
+
+	Rules []Rule `mapstructure:"rules" yaml:"rules,omitempty"`
+}
+
+// Apply is the configuration for applying the rules.
+type Apply struct {
+	// MaxActions is the maximum number of actions run by all the rules in a
+	// single run. Going over it aborts the run. 0 means no limit.
+	MaxActions int `mapstructure:"max_actions" yaml:"max_actions"`
 }
 
 // Cache is the configuration for the cache file.
 type Cache struct {
 	// The path to the cache file.
-	Path string `mapstructure:"path"`
+	Path string `mapstructure:"path" yaml:"path"`
 
 	// The time-to-live of the cache in hours.
-	TTLInHours int `mapstructure:"ttl_in_hours"`
+	TTLInHours int `mapstructure:"ttl_in_hours" yaml:"ttl_in_hours"`
+
+	// The number of events kept in each notification's history, 0 keeps none.
+	HistorySize int `mapstructure:"history_size" yaml:"history_size"`
 }
 
 // Enrichment is the configuration for notification enrichment.
 type Enrichment struct {
 	// Workers is the number of notifications to enrich concurrently.
-	Workers int `mapstructure:"workers"`
+	Workers int `mapstructure:"workers" yaml:"workers"`
+}
+
+// JQ is the configuration for the jq filters.
+type JQ struct {
+	// Definitions are jq functions shared by all the filters.
+	// E.g. `def ours: .repository.owner.login == "org";`
+	Definitions string `mapstructure:"definitions" yaml:"definitions"`
+
+	// LibraryPath is a list of directories containing `.jq` modules, relative
+	// to the directory of the config file declaring them. All the modules are
+	// included in every filter.
+	LibraryPath []string `mapstructure:"library_path" yaml:"library_path"`
 }
 
 // View is the configuration for the terminal view.
 type View struct {
 	// Number of notifications to display at once.
-	Height int `mapstructure:"height"`
+	Height int `mapstructure:"height" yaml:"height"`
 	// Where to write logs when REPL is showing.
-	LogPath string `mapstructure:"log_path"`
+	LogPath string `mapstructure:"log_path" yaml:"log_path"`
+	// How often the REPL refreshes the notifications, 0 disables it.
+	RefreshIntervalInMinutes int `mapstructure:"refresh_interval_in_minutes" yaml:"refresh_interval_in_minutes"`
+	// Columns to display, see Column. Defaults to notifications.DefaultColumns.
+	Columns []Column `mapstructure:"columns" yaml:"columns"`
+	// Named templates to use with `--format`, see notifications.NewTemplate.
+	Templates map[string]string `mapstructure:"templates" yaml:"templates"`
 }
 
 func Default(path string) (*viper.Viper, string) {
@@ -89,8 +166,13 @@ func Default(path string) (*viper.Viper, string) {
 	return v, path
 }
 
+// New loads the configuration from the path, with the named profile merged on
+// top of it. An empty profile uses the top-level configuration only.
+// The `GHNOT_` environment variables and the `key=value` overrides take
+// precedence over the file.
+//
 //revive:disable:cognitive-complexity // TODO: simplify.
-func New(path string) (*Config, error) {
+func New(path, profile string, overrides []string) (*Config, error) {
 	path, err := ExpandPathWithoutTilde(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand config path: %w", err)
@@ -100,6 +182,16 @@ func New(path string) (*Config, error) {
 	slog.Debug("loading configuration", "path", path)
 	c := &Config{viper: v, Path: path}
 
+	if err = c.bindEnv(); err != nil {
+		return nil, err
+	}
+
+	if err = c.override(overrides); err != nil {
+		return nil, err
+	}
+
+	found := true
+
 	if err = c.viper.ReadInConfig(); err != nil {
 		var viperNotFoundError viper.ConfigFileNotFoundError
 		if !errors.As(err, &viperNotFoundError) &&
@@ -108,18 +200,47 @@ func New(path string) (*Config, error) {
 		}
 
 		slog.Warn("Config file not found, using default")
+
+		found = false
+	} else if err = c.loadIncludes(); err != nil {
+		return nil, err
 	}
 
 	c.Path = c.viper.ConfigFileUsed()
 
-	if err = c.viper.Unmarshal(&c.Data); err != nil {
+	if err = c.loadProfile(profile); err != nil {
+		return nil, err
+	}
+
+	if err = c.viper.Unmarshal(&c.Data, decodeHook()); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	c.setOrigins()
+
+	if err = c.loadJQLibrary(); err != nil {
+		return nil, err
+	}
+
+	outdated := found && c.Data.Version < CurrentVersion
+	if outdated {
+		slog.Warn("Config file is in an old format, run 'gh-not config migrate'",
+			"version", c.Data.Version, "current", CurrentVersion)
+	}
+
 	if err = c.ValidateRules(); err != nil {
+		if outdated {
+			return nil, fmt.Errorf("%w\n\nThe config is in version %d, run 'gh-not config migrate' to update it to version %d",
+				err, c.Data.Version, CurrentVersion)
+		}
+
 		return nil, err
 	}
 
+	if _, err = c.Data.View.RenderColumns(); err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+
 	c.Data.Cache.Path, err = ExpandPathWithoutTilde(c.Data.Cache.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand cache path: %w", err)
@@ -128,8 +249,86 @@ func New(path string) (*Config, error) {
 	return c, nil
 }
 
+// loadProfile merges the profile's sections on top of the configuration.
+// A profile without its own cache path gets a separate cache file, even if the
+// top level sets one, as it may point at another host.
+func (c *Config) loadProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	key := "profiles." + name
+	if !c.viper.IsSet(key) {
+		return fmt.Errorf("%w: %s", errProfileNotFound, name)
+	}
+
+	profile := maps.Clone(c.viper.GetStringMap(key))
+
+	if !c.viper.IsSet(key + ".cache.path") {
+		cache, _ := profile["cache"].(map[string]any)
+		cache = maps.Clone(cache)
+
+		if cache == nil {
+			cache = map[string]any{}
+		}
+
+		cache["path"] = path.Join(StateDir(), "cache."+name+".json")
+		profile["cache"] = cache
+	}
+
+	if err := c.viper.MergeConfigMap(profile); err != nil {
+		return fmt.Errorf("failed to merge profile %s: %w", name, err)
+	}
+
+	if rules, ok := c.viper.Get(key + ".rules").([]any); ok {
+		file, ok := c.profileFiles[name]
+		if !ok {
+			file = c.Path
+		}
+
+		c.origins = make([]Origin, len(rules))
+		for i := range rules {
+			c.origins[i] = Origin{File: file, Profile: name, Index: i}
+		}
+	}
+
+	c.Profile = name
+
+	return nil
+}
+
+func (c *Config) loadJQLibrary() error {
+	paths := make([]string, 0, len(c.Data.JQ.LibraryPath))
+
+	dir := Dir()
+	if file := cmp.Or(c.libraryFile, c.Path); file != "" {
+		dir = filepath.Dir(file)
+	}
+
+	for _, path := range c.Data.JQ.LibraryPath {
+		path, err := ExpandPathWithoutTilde(path)
+		if err != nil {
+			return fmt.Errorf("failed to expand jq library path: %w", err)
+		}
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		paths = append(paths, path)
+	}
+
+	library, err := jq.NewLibrary(c.Data.JQ.Definitions, paths)
+	if err != nil {
+		return fmt.Errorf("failed to load the jq library: %w", err)
+	}
+
+	jq.SetLibrary(library)
+
+	return nil
+}
+
 func (c *Config) Marshal() ([]byte, error) {
-	//nolint:musttag // The struct is annotated with `mapstructure` tags already
 	marshaled, err := yaml.Marshal(c.Data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
@@ -149,14 +348,18 @@ func (c *Config) ValidateRules() error {
 
 		errorStr := dent.IndentString(strings.Join(violations, "\n"), "  - ")
 
-		//nolint:musttag // The struct is annotated with `mapstructure` tags already
 		yml, err := yaml.Marshal(rule)
 		if err != nil {
 			slog.Error("failed to marshal rule", "err", err)
 		}
 
+		origin := rule.Origin.String()
+		if rule.Origin.File == "" {
+			origin = fmt.Sprintf("index %d", i)
+		}
+
 		valErr := fmt.Sprintf(validationErrorStr,
-			i,
+			origin,
 			dent.IndentString(string(yml), "  "), errorStr)
 		validationErrors = append(validationErrors, valErr)
 	}
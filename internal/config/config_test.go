@@ -0,0 +1,363 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `
+cache:
+  ttl_in_hours: 2
+endpoint:
+  per_page: 10
+view:
+  height: 12
+rules:
+  - name: top
+    filters: [.unread]
+    action: hide
+profiles:
+  work:
+    host: github.example.com
+    endpoint:
+      per_page: 50
+    rules:
+      - name: work
+        filters: [.unread]
+        action: tag
+        args: [work]
+  other:
+    cache:
+      path: /tmp/other.json
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestNewProfile(t *testing.T) {
+	t.Parallel()
+
+	p := writeConfig(t, profilesConfig)
+
+	t.Run("no profile", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := New(p, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Data.Host != "" {
+			t.Errorf("want no host, got %q", c.Data.Host)
+		}
+
+		if len(c.Data.Rules) != 1 || c.Data.Rules[0].Name != "top" {
+			t.Errorf("want the top-level rules, got %#v", c.Data.Rules)
+		}
+
+		if c.Data.Endpoint.PerPage != 10 {
+			t.Errorf("want per_page 10, got %d", c.Data.Endpoint.PerPage)
+		}
+	})
+
+	t.Run("overrides the top level", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := New(p, "work", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Profile != "work" {
+			t.Errorf("want profile work, got %q", c.Profile)
+		}
+
+		if c.Data.Host != "github.example.com" {
+			t.Errorf("want host github.example.com, got %q", c.Data.Host)
+		}
+
+		if len(c.Data.Rules) != 1 || c.Data.Rules[0].Name != "work" {
+			t.Errorf("want the profile rules, got %#v", c.Data.Rules)
+		}
+
+		if c.Data.Endpoint.PerPage != 50 {
+			t.Errorf("want per_page 50, got %d", c.Data.Endpoint.PerPage)
+		}
+
+		if c.Data.View.Height != 12 || c.Data.Cache.TTLInHours != 2 {
+			t.Errorf("want the inherited view and cache, got %#v and %#v", c.Data.View, c.Data.Cache)
+		}
+
+		want := path.Join(StateDir(), "cache.work.json")
+		if c.Data.Cache.Path != want {
+			t.Errorf("want cache path %q, got %q", want, c.Data.Cache.Path)
+		}
+	})
+
+	t.Run("uses the profile cache path", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := New(p, "other", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Data.Cache.Path != "/tmp/other.json" {
+			t.Errorf("want cache path /tmp/other.json, got %q", c.Data.Cache.Path)
+		}
+
+		if c.Data.Cache.TTLInHours != 2 {
+			t.Errorf("want the inherited ttl, got %d", c.Data.Cache.TTLInHours)
+		}
+	})
+
+	t.Run("ignores the top-level cache path", func(t *testing.T) {
+		t.Parallel()
+
+		p := writeConfig(t, "cache:\n  path: /tmp/main.json\n"+strings.TrimPrefix(profilesConfig, "\ncache:\n"))
+
+		c, err := New(p, "work", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := path.Join(StateDir(), "cache.work.json")
+		if c.Data.Cache.Path != want {
+			t.Errorf("want cache path %q, got %q", want, c.Data.Cache.Path)
+		}
+
+		if c.Data.Cache.TTLInHours != 2 {
+			t.Errorf("want the inherited ttl, got %d", c.Data.Cache.TTLInHours)
+		}
+
+		if c, err = New(p, "", nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Data.Cache.Path != "/tmp/main.json" {
+			t.Errorf("want cache path /tmp/main.json, got %q", c.Data.Cache.Path)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := New(p, "nope", nil); !errors.Is(err, errProfileNotFound) {
+			t.Errorf("want %v, got %v", errProfileNotFound, err)
+		}
+	})
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	p := writeConfig(t, `
+apply:
+  max_actions: 5
+cache:
+  history_size: 3
+endpoint:
+  max_retry: 2
+jq:
+  library_path: [lib]
+view:
+  refresh_interval_in_minutes: 4
+rules:
+  - name: test
+    filters: [.unread]
+    action: tag
+    args: [a]
+    max_per_run: 3
+    enabled: true
+`)
+
+	c, err := New(p, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshaled, err := c.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"max_actions: 5", "history_size: 3", "max_retry: 2", "library_path:", "max_per_run: 3"} {
+		if !strings.Contains(string(marshaled), key) {
+			t.Errorf("want %q in\n%s", key, marshaled)
+		}
+	}
+
+	if strings.Contains(string(marshaled), "null") {
+		t.Errorf("want no null values in\n%s", marshaled)
+	}
+
+	c, err = New(writeConfig(t, string(marshaled)), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ValidateStrict(); err != nil {
+		t.Errorf("want the marshaled config to be valid, got %v", err)
+	}
+
+	remarshaled, err := c.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(remarshaled) != string(marshaled) {
+		t.Errorf("want the same config, got\n%s\nand\n%s", marshaled, remarshaled)
+	}
+}
+
+func TestNewInclude(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"common.yaml": `
+cache:
+  ttl_in_hours: 3
+view:
+  height: 5
+rules:
+  - name: common
+    filters: [.unread]
+    action: hide
+`,
+		"rules.d/0.yaml": `
+rules:
+  - name: d0
+    filters: [.unread]
+    action: hide
+`,
+		"rules.d/1.yaml": `
+rules:
+  - name: d1
+    filters: [.unread]
+    action: hide
+  - name: d1 invalid
+    filters: [.unread]
+`,
+	}
+
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("merges the includes", func(t *testing.T) {
+		t.Parallel()
+
+		p := writeConfig(t, `
+include:
+  - `+filepath.Join(dir, "common.yaml")+`
+  - `+filepath.Join(dir, "rules.d", "0.yaml")+`
+view:
+  height: 7
+rules:
+  - name: main
+    filters: [.unread]
+    action: hide
+`)
+
+		c, err := New(p, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Data.View.Height != 7 || c.Data.Cache.TTLInHours != 3 {
+			t.Errorf("want the merged view and cache, got %#v and %#v", c.Data.View, c.Data.Cache)
+		}
+
+		want := []Origin{
+			{File: filepath.Join(dir, "common.yaml"), Index: 0},
+			{File: filepath.Join(dir, "rules.d", "0.yaml"), Index: 0},
+			{File: p, Index: 0},
+		}
+		names := []string{"common", "d0", "main"}
+
+		if len(c.Data.Rules) != len(want) {
+			t.Fatalf("want %d rules, got %#v", len(want), c.Data.Rules)
+		}
+
+		for i, rule := range c.Data.Rules {
+			if rule.Name != names[i] || rule.Origin != want[i] {
+				t.Errorf("rule %d: want %s from %v, got %s from %v", i, names[i], want[i], rule.Name, rule.Origin)
+			}
+		}
+	})
+
+	t.Run("reports the invalid rule's file", func(t *testing.T) {
+		t.Parallel()
+
+		p := writeConfig(t, "include: ["+filepath.Join(dir, "rules.d", "*.yaml")+"]")
+
+		_, err := New(p, "", nil)
+		if !errors.Is(err, errRuleValidation) {
+			t.Fatalf("want %v, got %v", errRuleValidation, err)
+		}
+
+		want := "index 1 in " + filepath.Join(dir, "rules.d", "1.yaml")
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("want %q in %q", want, err)
+		}
+	})
+
+	t.Run("missing include", func(t *testing.T) {
+		t.Parallel()
+
+		p := writeConfig(t, "include: ["+filepath.Join(dir, "nope.yaml")+"]")
+
+		if _, err := New(p, "", nil); !errors.Is(err, errIncludeNotFound) {
+			t.Errorf("want %v, got %v", errIncludeNotFound, err)
+		}
+	})
+}
+
+//nolint:paralleltest // The jq library is global.
+func TestNewJQLibraryPath(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(dir, "jq"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "jq", "org.jq"), []byte(`def ours: true;`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(p, []byte(`
+jq:
+  library_path: [jq]
+rules:
+  - filters: [ours]
+    action: hide
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New(p, "", nil); err != nil {
+		t.Fatalf("want the library next to the config file, got %v", err)
+	}
+}
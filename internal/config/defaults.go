@@ -4,8 +4,11 @@ import "path"
 
 //nolint:gochecknoglobals,mnd,goconst // This is used as a default in a couple of places.
 var Defaults = map[string]any{
+	"apply.max_actions": 0,
+
 	"cache.ttl_in_hours": 1,
 	"cache.path":         path.Join(StateDir(), "cache.json"),
+	"cache.history_size": 50,
 
 	"endpoint.all":       true,
 	"endpoint.max_retry": 10,
@@ -14,28 +17,39 @@ var Defaults = map[string]any{
 
 	"enrichment.workers": 1,
 
-	"view.height":   40,
-	"view.log_path": path.Join(StateDir(), "debug.log"),
+	"view.height":                      40,
+	"view.log_path":                    path.Join(StateDir(), "debug.log"),
+	"view.refresh_interval_in_minutes": 0,
 
 	"rules": []Rule{},
 
-	"keymap.normal.cursor up":       []string{"up", "k"},
-	"keymap.normal.cursor down":     []string{"down", "j"},
-	"keymap.normal.next page":       []string{"right", "l"},
-	"keymap.normal.previous page":   []string{"left", "h"},
-	"keymap.normal.go to start":     []string{"home", "g"},
-	"keymap.normal.go to end":       []string{"end", "G"},
-	"keymap.normal.toggle selected": []string{" "},
-	"keymap.normal.select all":      []string{"a"},
-	"keymap.normal.select none":     []string{"A"},
-	"keymap.normal.open in browser": []string{"o"},
-	"keymap.normal.filter mode":     []string{"/"},
-	"keymap.normal.command mode":    []string{":"},
-	"keymap.normal.toggle help":     []string{"?"},
-	"keymap.normal.quit":            []string{"q", "esc"},
-	"keymap.normal.force quit":      []string{"ctrl+c"},
-	"keymap.filter.filter accept":   []string{"enter"},
-	"keymap.filter.filter cancel":   []string{"esc"},
-	"keymap.command.command accept": []string{"enter"},
-	"keymap.command.command cancel": []string{"esc"},
+	"keymap.normal.cursor up":        []string{"up", "k"},
+	"keymap.normal.cursor down":      []string{"down", "j"},
+	"keymap.normal.next page":        []string{"right", "l"},
+	"keymap.normal.previous page":    []string{"left", "h"},
+	"keymap.normal.go to start":      []string{"home", "g"},
+	"keymap.normal.go to end":        []string{"end", "G"},
+	"keymap.normal.toggle selected":  []string{" "},
+	"keymap.normal.select all":       []string{"a"},
+	"keymap.normal.select none":      []string{"A"},
+	"keymap.normal.open in browser":  []string{"o"},
+	"keymap.normal.filter mode":      []string{"/"},
+	"keymap.normal.command mode":     []string{":"},
+	"keymap.normal.toggle help":      []string{"?"},
+	"keymap.normal.toggle preview":   []string{"p"},
+	"keymap.normal.jq filter mode":   []string{"\\"},
+	"keymap.normal.rule filter mode": []string{"r"},
+	"keymap.normal.refresh":          []string{"R"},
+	"keymap.normal.quit":             []string{"q", "esc"},
+	"keymap.normal.force quit":       []string{"ctrl+c"},
+	"keymap.filter.filter accept":    []string{"enter"},
+	"keymap.filter.filter cancel":    []string{"esc"},
+	"keymap.command.command accept":  []string{"enter"},
+	"keymap.command.command cancel":  []string{"esc"},
+	"keymap.jq.jq accept":            []string{"enter"},
+	"keymap.jq.jq cancel":            []string{"esc"},
+	"keymap.jq.history previous":     []string{"up"},
+	"keymap.jq.history next":         []string{"down"},
+	"keymap.rule.rule accept":        []string{"enter"},
+	"keymap.rule.rule cancel":        []string{"esc"},
 }
@@ -0,0 +1,84 @@
+package config
+
+import "strings"
+
+// diffContext is the number of unchanged lines shown around the changes.
+const diffContext = 2
+
+// diff returns the lines removed from `before`, prefixed with `-`, and added
+// in `after`, prefixed with `+`, with a few unchanged lines around them.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func diff(before, after string) string {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		prefix string
+		text   string
+	}
+
+	lines := []line{}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{" ", a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{"-", a[i]})
+			i++
+		default:
+			lines = append(lines, line{"+", b[j]})
+			j++
+		}
+	}
+
+	// Only keep the changed lines and their context.
+	keep := make([]bool, len(lines))
+
+	for k, l := range lines {
+		if l.prefix == " " {
+			continue
+		}
+
+		for c := max(0, k-diffContext); c <= min(len(lines)-1, k+diffContext); c++ {
+			keep[c] = true
+		}
+	}
+
+	out := []string{}
+
+	for k, l := range lines {
+		if !keep[k] {
+			continue
+		}
+
+		if k > 0 && !keep[k-1] && len(out) > 0 {
+			out = append(out, "...")
+		}
+
+		out = append(out, l.prefix+" "+l.text)
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+var errIncludeNotFound = errors.New("include matches no file")
+
+// Origin is where a Rule is declared.
+type Origin struct {
+	// File is the config file declaring the rule.
+	File string
+
+	// Profile is the profile declaring the rule, if any.
+	Profile string
+
+	// Index is the position of the rule in the file's or profile's rules.
+	Index int
+}
+
+func (o Origin) String() string {
+	if o.Profile != "" {
+		return fmt.Sprintf("index %d of profile %s in %s", o.Index, o.Profile, o.File)
+	}
+
+	return fmt.Sprintf("index %d in %s", o.Index, o.File)
+}
+
+// includeFiles returns the files matching the `include` patterns, in order.
+func (c *Config) includeFiles() ([]string, error) {
+	return IncludeFiles(c.viper.GetStringSlice("include"))
+}
+
+// IncludeFiles returns the files matching the patterns, in order.
+// Relative patterns are relative to Dir().
+func IncludeFiles(patterns []string) ([]string, error) {
+	var files []string
+
+	for _, pattern := range patterns {
+		pattern, err := ExpandPathWithoutTilde(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand include path: %w", err)
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(Dir(), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include %s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: %s", errIncludeNotFound, pattern)
+		}
+
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// loadIncludes merges the included files and the main config file, in this
+// order, so that the main file has the last word.
+// The rules are not merged but concatenated: the included rules come first, in
+// include order, followed by the main file's rules.
+// Included files cannot include other files.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func (c *Config) loadIncludes() error {
+	files, err := c.includeFiles()
+	if err != nil {
+		return err
+	}
+
+	merged := viper.New()
+	rules := []any{}
+	c.origins = []Origin{}
+	c.profileFiles = map[string]string{}
+
+	for _, file := range append(files, c.Path) {
+		if file == "" {
+			continue
+		}
+
+		v := viper.New()
+		v.SetConfigFile(file)
+
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", file, err)
+		}
+
+		settings := v.AllSettings()
+
+		if fileRules, ok := settings["rules"].([]any); ok {
+			for i, rule := range fileRules {
+				rules = append(rules, rule)
+				c.origins = append(c.origins, Origin{File: file, Index: i})
+			}
+		}
+
+		if v.IsSet("jq.library_path") {
+			c.libraryFile = file
+		}
+
+		for name := range v.GetStringMap("profiles") {
+			if v.IsSet("profiles." + name + ".rules") {
+				c.profileFiles[name] = file
+			}
+		}
+
+		delete(settings, "rules")
+
+		if err := merged.MergeConfigMap(settings); err != nil {
+			return fmt.Errorf("failed to merge config file %s: %w", file, err)
+		}
+	}
+
+	settings := merged.AllSettings()
+	settings["rules"] = rules
+
+	if err := c.viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge the included config files: %w", err)
+	}
+
+	return nil
+}
+
+// setOrigins records where each rule is declared.
+func (c *Config) setOrigins() {
+	if len(c.origins) != len(c.Data.Rules) {
+		return
+	}
+
+	for i := range c.Data.Rules {
+		c.Data.Rules[i].Origin = c.origins[i]
+	}
+}
@@ -2,6 +2,9 @@
 package config
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -39,3 +42,34 @@ func (k Keymap) Binding(mode, action string) key.Binding {
 func (k KeyBinding) Help() string {
 	return strings.NewReplacer(unicodeReplacement...).Replace(strings.Join(k, "|"))
 }
+
+// Validate checks that the modes and actions are known, i.e. present in the
+// Defaults, and that no key is bound to several actions of the same mode.
+func (k Keymap) Validate() []string {
+	violations := []string{}
+
+	for _, mode := range slices.Sorted(maps.Keys(k)) {
+		bound := map[string]string{}
+
+		for _, action := range slices.Sorted(maps.Keys(k[mode])) {
+			if _, ok := Defaults["keymap."+mode+"."+action]; !ok {
+				violations = append(violations, fmt.Sprintf("unknown keymap action %q in mode %q", action, mode))
+
+				continue
+			}
+
+			for _, key := range k[mode][action] {
+				if other, ok := bound[key]; ok {
+					violations = append(violations,
+						fmt.Sprintf("key %q is bound to both %q and %q in mode %q", key, other, action, mode))
+
+					continue
+				}
+
+				bound[key] = action
+			}
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeymapValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		keymap Keymap
+		want   []string
+	}{
+		{
+			name: "valid",
+			keymap: Keymap{
+				"normal": {"quit": {"q"}, "toggle help": {"?"}},
+				"filter": {"filter cancel": {"q"}},
+			},
+		},
+		{
+			name:   "unknown mode",
+			keymap: Keymap{"insert": {"quit": {"q"}}},
+			want:   []string{`unknown keymap action "quit" in mode "insert"`},
+		},
+		{
+			name:   "unknown action",
+			keymap: Keymap{"normal": {"explode": {"x"}}},
+			want:   []string{`unknown keymap action "explode" in mode "normal"`},
+		},
+		{
+			name:   "clash",
+			keymap: Keymap{"normal": {"quit": {"q"}, "toggle help": {"?", "q"}}},
+			want:   []string{`key "q" is bound to both "quit" and "toggle help" in mode "normal"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.keymap.Validate()
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
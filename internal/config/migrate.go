@@ -0,0 +1,270 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nobe4/gh-not/internal/actions"
+)
+
+// CurrentVersion is the version of the configuration format.
+//
+// Changelog:
+//   - 0: initial format, without `version`.
+//   - 1: rules with actions that change GitHub need `confirm: true`.
+const CurrentVersion = 1
+
+var (
+	errInvalidConfig  = errors.New("invalid config file")
+	errFutureVersion  = errors.New("config version is newer than supported")
+	errInvalidVersion = errors.New("invalid config version")
+)
+
+// migrations holds the function migrating from the version at its index to the
+// next one. They return true if they changed the config.
+//
+//nolint:gochecknoglobals // This is a static list.
+var migrations = []func(root *yaml.Node) bool{
+	migrateV0,
+}
+
+// Migration is the result of migrating a config file.
+type Migration struct {
+	From   int
+	To     int
+	Before []byte
+	After  []byte
+
+	// Includes are the config's `include` patterns, their files need to be
+	// migrated with MigrateInclude.
+	Includes []string
+}
+
+// Changed returns true if the migration changed the config.
+func (m Migration) Changed() bool {
+	return m.From != m.To
+}
+
+// Diff returns the changed lines, with some context.
+func (m Migration) Diff() string {
+	return diff(string(m.Before), string(m.After))
+}
+
+// Migrate rewrites a config file's content from its version to the current
+// one. Comments and keys order are kept.
+func Migrate(content []byte) (Migration, error) {
+	m := Migration{Before: content, After: content}
+
+	doc, root, err := parse(content)
+	if err != nil {
+		return m, err
+	}
+
+	if include := getKey(root, "include"); include != nil {
+		for _, pattern := range include.Content {
+			m.Includes = append(m.Includes, pattern.Value)
+		}
+	}
+
+	version, err := nodeVersion(root)
+	if err != nil {
+		return m, err
+	}
+
+	m.From, m.To = version, version
+
+	if version > CurrentVersion {
+		return m, fmt.Errorf("%w: %d > %d", errFutureVersion, version, CurrentVersion)
+	}
+
+	if version == CurrentVersion {
+		return m, nil
+	}
+
+	for _, migrate := range migrations[version:] {
+		migrate(root)
+	}
+
+	setVersion(root)
+	m.To = CurrentVersion
+
+	if m.After, err = encode(doc); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
+// MigrateInclude rewrites an included file's content from the version of the
+// config including it to the current one. Included files don't have their own
+// version, and are left untouched if no migration changes them.
+func MigrateInclude(content []byte, from int) (Migration, error) {
+	m := Migration{From: from, To: from, Before: content, After: content}
+
+	doc, root, err := parse(content)
+	if err != nil {
+		return m, err
+	}
+
+	if from < 0 || from > CurrentVersion {
+		return m, fmt.Errorf("%w: %d", errInvalidVersion, from)
+	}
+
+	changed := false
+	for _, migrate := range migrations[from:] {
+		changed = migrate(root) || changed
+	}
+
+	if !changed {
+		return m, nil
+	}
+
+	m.To = CurrentVersion
+
+	if m.After, err = encode(doc); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
+func parse(content []byte) (*yaml.Node, *yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errInvalidConfig, err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("%w: not a mapping", errInvalidConfig)
+	}
+
+	return doc, root, nil
+}
+
+func encode(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal the migrated config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// setVersion sets the version to the current one, at the top of the file.
+func setVersion(root *yaml.Node) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(CurrentVersion)}
+
+	if getKey(root, "version") != nil {
+		setKey(root, "version", value)
+
+		return
+	}
+
+	root.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Value: "version"}, value}, root.Content...)
+}
+
+func nodeVersion(root *yaml.Node) (int, error) {
+	node := getKey(root, "version")
+	if node == nil {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidVersion, node.Value)
+	}
+
+	return version, nil
+}
+
+// migrateV0 confirms the rules running actions that change GitHub, as they
+// didn't need to before.
+func migrateV0(root *yaml.Node) bool {
+	changed := confirmRules(getKey(root, "rules"))
+
+	if profiles := getKey(root, "profiles"); profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 1; i < len(profiles.Content); i += 2 {
+			changed = confirmRules(getKey(profiles.Content[i], "rules")) || changed
+		}
+	}
+
+	return changed
+}
+
+// confirmRules sets `confirm: true` on the rules running an action that changes
+// GitHub, with `action` or `actions`, unless they already set `confirm`.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func confirmRules(rules *yaml.Node) bool {
+	if rules == nil || rules.Kind != yaml.SequenceNode {
+		return false
+	}
+
+	changed := false
+
+	for _, rule := range rules.Content {
+		if rule.Kind != yaml.MappingNode || getKey(rule, "confirm") != nil {
+			continue
+		}
+
+		names := []*yaml.Node{getKey(rule, "action")}
+		if list := getKey(rule, "actions"); list != nil {
+			for _, entry := range list.Content {
+				names = append(names, getKey(entry, "name"))
+			}
+		}
+
+		for _, name := range names {
+			if name != nil && actions.IsRemote(name.Value) {
+				setKey(rule, "confirm", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+
+				changed = true
+
+				break
+			}
+		}
+	}
+
+	return changed
+}
+
+// getKey returns the value of the key in a mapping node, or nil.
+func getKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setKey sets the value of the key in a mapping node, appending it if missing.
+func setKey(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+
+			return
+		}
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		want     string
+		from     int
+		includes []string
+	}{
+		{
+			name: "current version",
+			content: `version: 1
+rules: []
+`,
+			want: `version: 1
+rules: []
+`,
+			from: 1,
+		},
+		{
+			name: "empty",
+			want: `version: 1
+`,
+		},
+		{
+			name: "v0",
+			content: `# comment
+include: [rules.d/*.yaml]
+cache:
+  ttl_in_hours: 2
+rules:
+  - name: a
+    action: tag
+    args: [a]
+    actions:
+      - name: read
+  - action: hide
+  - action: done
+    confirm: false
+  - action: done
+profiles:
+  work:
+    rules:
+      - actions:
+          - name: assign
+            args: [me]
+`,
+			want: `version: 1
+# comment
+include: [rules.d/*.yaml]
+cache:
+  ttl_in_hours: 2
+rules:
+  - name: a
+    action: tag
+    args: [a]
+    actions:
+      - name: read
+    confirm: true
+  - action: hide
+  - action: done
+    confirm: false
+  - action: done
+    confirm: true
+profiles:
+  work:
+    rules:
+      - actions:
+          - name: assign
+            args: [me]
+        confirm: true
+`,
+			includes: []string{"rules.d/*.yaml"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := Migrate([]byte(test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.From != test.from || m.To != CurrentVersion {
+				t.Errorf("want from %d to %d, got from %d to %d", test.from, CurrentVersion, m.From, m.To)
+			}
+
+			if string(m.After) != test.want {
+				t.Errorf("want\n%s\ngot\n%s", test.want, m.After)
+			}
+
+			if !slices.Equal(m.Includes, test.includes) {
+				t.Errorf("want includes %v, got %v", test.includes, m.Includes)
+			}
+		})
+	}
+}
+
+func TestMigrateInclude(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		from    int
+		want    string
+		changed bool
+	}{
+		{
+			name: "v0",
+			content: `# shared rules
+rules:
+  - action: read
+  - action: hide
+`,
+			want: `# shared rules
+rules:
+  - action: read
+    confirm: true
+  - action: hide
+`,
+			changed: true,
+		},
+		{
+			name: "v0 without remote actions",
+			content: `rules:
+    - action:   hide
+`,
+			want: `rules:
+    - action:   hide
+`,
+		},
+		{
+			name: "current version",
+			content: `rules:
+  - action: read
+`,
+			from: 1,
+			want: `rules:
+  - action: read
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := MigrateInclude([]byte(test.content), test.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Changed() != test.changed {
+				t.Errorf("want changed %v, got %v", test.changed, m.Changed())
+			}
+
+			if string(m.After) != test.want {
+				t.Errorf("want\n%s\ngot\n%s", test.want, m.After)
+			}
+		})
+	}
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		want    error
+	}{
+		{content: "- a", want: errInvalidConfig},
+		{content: "version: a", want: errInvalidVersion},
+		{content: "version: 99", want: errFutureVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.content, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Migrate([]byte(test.content)); !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\nI\n"
+	want := `  a
+- b
++ B
+  c
+  d
+...
+  g
+  h
++ I`
+
+	if got := diff(before, after); got != want {
+		t.Errorf("want\n%s\ngot\n%s", want, got)
+	}
+}
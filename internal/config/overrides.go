@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix is the prefix of the environment variables overriding the config.
+const envPrefix = "GHNOT"
+
+var errInvalidOverride = errors.New("invalid override, want key=value")
+
+// bindEnv makes every key overridable with an environment variable, prefixed
+// with `GHNOT_` and with `.` and spaces replaced by `_`.
+// E.g. `GHNOT_ENDPOINT_MAX_PAGE=20` overrides `endpoint.max_page`.
+func (c *Config) bindEnv() error {
+	c.viper.SetEnvPrefix(envPrefix)
+	c.viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", " ", "_"))
+	c.viper.AutomaticEnv()
+
+	// AutomaticEnv only looks up the keys viper knows about, i.e. the ones in
+	// the Defaults or the config file. Binding all the keys makes the others
+	// overridable too.
+	for _, key := range keys(reflect.TypeFor[Data](), "") {
+		if err := c.viper.BindEnv(key); err != nil {
+			return fmt.Errorf("failed to bind the environment for %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// keys returns the config keys of a struct's fields, recursively.
+// Maps are skipped, binding them would hide their nested keys.
+func keys(t reflect.Type, prefix string) []string {
+	out := []string{}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Map {
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			out = append(out, keys(field.Type, prefix+name+".")...)
+
+			continue
+		}
+
+		out = append(out, prefix+name)
+	}
+
+	return out
+}
+
+// override sets the values of `key=value` overrides, which take precedence
+// over the environment and the config file.
+// The value is parsed as YAML, e.g. `view.height=10` or `include=[a.yaml]`.
+func (c *Config) override(overrides []string) error {
+	for _, o := range overrides {
+		key, raw, ok := strings.Cut(o, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: %q", errInvalidOverride, o)
+		}
+
+		var value any = raw
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return fmt.Errorf("%w: %q: %w", errInvalidOverride, o, err)
+		}
+
+		if value == nil {
+			value = raw
+		}
+
+		c.viper.Set(strings.TrimSpace(key), value)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+//nolint:paralleltest // t.Setenv forbids running tests in parallel.
+func TestNewOverrides(t *testing.T) {
+	p := writeConfig(t, `
+endpoint:
+  max_page: 1
+  per_page: 2
+view:
+  height: 3
+`)
+
+	t.Setenv("GHNOT_ENDPOINT_MAX_PAGE", "20")
+	t.Setenv("GHNOT_HOST", "github.example.com")
+	t.Setenv("GHNOT_VIEW_HEIGHT", "30")
+	t.Setenv("GHNOT_KEYMAP_NORMAL_QUIT", "x")
+
+	c, err := New(p, "", []string{"view.height=10", "include=[]", "enrichment.workers=4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Data.Endpoint.MaxPage != 20 || c.Data.Endpoint.PerPage != 2 {
+		t.Errorf("want the environment to override the file, got %#v", c.Data.Endpoint)
+	}
+
+	if c.Data.Host != "github.example.com" {
+		t.Errorf("want the environment to set keys without defaults, got %q", c.Data.Host)
+	}
+
+	if c.Data.View.Height != 10 || c.Data.Enrichment.Workers != 4 {
+		t.Errorf("want the overrides to take precedence, got %#v and %#v", c.Data.View, c.Data.Enrichment)
+	}
+
+	if !slices.Equal(c.Data.Keymap["normal"]["quit"], KeyBinding{"x"}) {
+		t.Errorf("want the keymap to be overridden, got %#v", c.Data.Keymap["normal"]["quit"])
+	}
+
+	if len(c.Data.Keymap["normal"]) < 2 {
+		t.Errorf("want the other keymap defaults, got %#v", c.Data.Keymap["normal"])
+	}
+
+	if _, err := New(p, "", []string{"view.height"}); !errors.Is(err, errInvalidOverride) {
+		t.Errorf("want %v, got %v", errInvalidOverride, err)
+	}
+}
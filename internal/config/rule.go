@@ -1,7 +1,11 @@
 package config
 
 import (
+	"cmp"
 	"fmt"
+	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/nobe4/gh-not/internal/actions"
 	"github.com/nobe4/gh-not/internal/jq"
@@ -21,11 +25,40 @@ import (
 //
 //	  - name: ignore ci failures for the current repo
 //	    action: done
+//	    confirm: true
+//	    max_per_run: 50
 //	    filters:
 //	      - .repository.full_name == "nobe4/gh-not"
 //	      - .reason == "ci_activity"
+//
+//	  - name: tag and read bots, then stop
+//	    priority: 10
+//	    continue: false
+//	    actions:
+//	      - name: tag
+//	        args: [bot]
+//	      - name: read
+//	    confirm: true
+//	    filters:
+//	      - .author.type == "Bot"
 type Rule struct {
-	Name string `mapstructure:"name"`
+	Name string `mapstructure:"name" yaml:"name,omitempty"`
+
+	// Enabled toggles the rule without removing it from the config.
+	// A missing value means the rule is enabled.
+	Enabled *bool `mapstructure:"enabled" yaml:"enabled,omitempty"`
+
+	// Priority orders the rules: higher priorities run first.
+	// Rules with the same priority run in declaration order.
+	Priority int `mapstructure:"priority" yaml:"priority,omitempty"`
+
+	// Continue controls whether the next rules are applied to the notifications
+	// matched by this rule. A missing value means the processing continues.
+	Continue *bool `mapstructure:"continue" yaml:"continue,omitempty"`
+
+	// Schedule restricts when the rule is applied.
+	// A missing value means the rule is always applied.
+	Schedule *Schedule `mapstructure:"schedule" yaml:"schedule,omitempty"`
 
 	// Filters is a list of jq filters to filter the notifications.
 	// The filters are applied in order, like they are joined by 'and'.
@@ -34,34 +67,153 @@ type Rule struct {
 	// E.g.:
 	// filters: ["A", "B or C"]
 	// Will filter `A and (B or C)`.
-	Filters []string `mapstructure:"filters"`
+	Filters []string `mapstructure:"filters" yaml:"filters,omitempty"`
 
 	// Action is the action to take on the filtered notifications.
 	// See github.com/nobe4/internal/actions for list of available actions.
-	Action string `mapstructure:"action"`
+	Action string `mapstructure:"action" yaml:"action,omitempty"`
 
 	// Args is the arguments to pass to the Action.
-	Args []string `mapstructure:"args"`
+	Args []string `mapstructure:"args" yaml:"args,omitempty"`
+
+	// Actions is a list of actions to take on the filtered notifications.
+	// They are run in order, after Action if it's also set.
+	Actions []RuleAction `mapstructure:"actions" yaml:"actions,omitempty"`
+
+	// Confirm allows the rule to run actions that change the notifications on
+	// GitHub, e.g. read, done or assign.
+	Confirm bool `mapstructure:"confirm" yaml:"confirm,omitempty"`
+
+	// MaxPerRun is the maximum number of notifications the rule can act on in
+	// a single run. Going over it aborts the run. 0 means no limit.
+	MaxPerRun int `mapstructure:"max_per_run" yaml:"max_per_run,omitempty"`
+
+	// Tests is a list of sample notifications with the expected match result.
+	// They are run with `gh-not config test`.
+	//
+	// E.g.:
+	// tests:
+	//   - name: matches bots
+	//     notification: {"author": {"type": "Bot"}}
+	//     match: true
+	Tests []RuleTest `mapstructure:"tests" yaml:"tests,omitempty"`
+
+	// Origin is where the rule is declared, it's set when loading the config.
+	Origin Origin `mapstructure:"-" yaml:"-"`
+}
+
+// RuleAction is a single action, with its arguments, run by a Rule.
+type RuleAction struct {
+	// Name is the action to run.
+	// See github.com/nobe4/internal/actions for list of available actions.
+	Name string `mapstructure:"name" yaml:"name,omitempty"`
+
+	// Args is the arguments to pass to the action.
+	Args []string `mapstructure:"args" yaml:"args,omitempty"`
+}
+
+// IsEnabled returns true if the rule should be applied.
+func (r Rule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// Scheduled returns true if the rule's schedule includes the time t.
+// An invalid schedule is never active.
+func (r Rule) Scheduled(t time.Time) bool {
+	active, err := r.Schedule.Active(t)
+	if err != nil {
+		slog.Warn("invalid schedule", "rule", r.Name, "err", err)
+
+		return false
+	}
+
+	return active
+}
+
+// Continues returns true if the next rules should be applied on the
+// notifications matched by this rule.
+func (r Rule) Continues() bool {
+	return r.Continue == nil || *r.Continue
+}
+
+// ActionList returns all the actions to run, in order.
+// The single Action comes first, followed by the Actions list.
+func (r Rule) ActionList() []RuleAction {
+	list := make([]RuleAction, 0, len(r.Actions)+1)
+
+	if r.Action != "" {
+		list = append(list, RuleAction{Name: r.Action, Args: r.Args})
+	}
+
+	return append(list, r.Actions...)
+}
+
+// SortRules returns a copy of the rules, sorted by descending priority.
+// Rules with the same priority keep their declaration order.
+func SortRules(rules []Rule) []Rule {
+	sorted := make([]Rule, 0, len(rules))
+
+	for _, i := range RuleOrder(rules) {
+		sorted = append(sorted, rules[i])
+	}
+
+	return sorted
 }
 
-// Validate tests the rule for correctness. A rule must have an action and at least one filter.
+// RuleOrder returns the indexes of the rules in the order of SortRules.
+func RuleOrder(rules []Rule) []int {
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(rules[b].Priority, rules[a].Priority)
+	})
+
+	return order
+}
+
+// Validate tests the rule for correctness. A rule must have at least one
+// action and at least one filter.
 func (r Rule) Validate() []string {
 	var violations []string
 
 	actionsMap := actions.GetMap(nil)
 
-	if _, ok := actionsMap[r.Action]; !ok {
-		if r.Action == "" {
+	ruleActions := r.ActionList()
+	if len(ruleActions) == 0 {
+		violations = append(violations, "rule action is empty")
+	}
+
+	for _, action := range ruleActions {
+		if action.Name == "" {
 			violations = append(violations, "rule action is empty")
-		} else {
-			violations = append(violations, fmt.Sprintf("invalid rule action: \"%v\"", r.Action))
+
+			continue
+		}
+
+		if _, ok := actionsMap[action.Name]; !ok {
+			violations = append(violations, fmt.Sprintf("invalid rule action: \"%v\"", action.Name))
+		}
+
+		if actions.IsRemote(action.Name) && !r.Confirm {
+			violations = append(violations,
+				fmt.Sprintf("rule action \"%v\" changes GitHub: add `confirm: true` to the rule, "+
+					"or run 'gh-not config migrate' to confirm all such rules", action.Name))
 		}
 	}
 
+	if r.MaxPerRun < 0 {
+		violations = append(violations, "max_per_run cannot be negative")
+	}
+
 	if len(r.Filters) == 0 {
 		violations = append(violations, "rule has no filters")
 	}
 
+	violations = append(violations, r.Schedule.Validate()...)
+
 	for _, filter := range r.Filters {
 		if err := jq.Validate(filter); err != nil {
 			violations = append(violations, fmt.Sprintf("invalid jq filter %s: %v", filter, err))
@@ -73,16 +225,58 @@ func (r Rule) Validate() []string {
 
 // Filter filters the notifications with the jq filters and returns the IDs.
 func (r Rule) Filter(n notifications.Notifications) (notifications.Notifications, error) {
+	if len(r.Filters) == 0 {
+		return n, nil
+	}
+
+	raw, err := jq.NewRaw(n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter notifications: %w", err)
+	}
+
+	return r.FilterRaw(raw, n)
+}
+
+// FilterRaw is like Filter, but reuses already converted notifications.
+func (r Rule) FilterRaw(raw *jq.Raw, n notifications.Notifications) (notifications.Notifications, error) {
 	var err error
 
-	// TODO: accept only a single filter so that we can use the jq.Filter function
-	// once instead of looping over the filters.
-	// Given that it's joined by `and`, we can just write the filters directly.
 	for _, filter := range r.Filters {
-		if n, err = jq.Filter(filter, n); err != nil {
+		if n, err = raw.Filter(filter, n); err != nil {
 			return nil, fmt.Errorf("failed to filter notifications: %w", err)
 		}
 	}
 
 	return n, nil
 }
+
+// FilterResult is the result of a single filter evaluated on a notification.
+type FilterResult struct {
+	Filter  string
+	Matched bool
+	Err     error
+}
+
+// Explain evaluates each filter on a single notification.
+// Unlike Filter, all filters are evaluated, so that it's possible to see
+// which ones match and which ones don't.
+func (r Rule) Explain(n *notifications.Notification) ([]FilterResult, bool) {
+	results := make([]FilterResult, 0, len(r.Filters))
+	matched := true
+
+	for _, filter := range r.Filters {
+		result := FilterResult{Filter: filter}
+
+		filtered, err := jq.Filter(filter, notifications.Notifications{n})
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Matched = len(filtered) > 0
+		}
+
+		matched = matched && result.Matched
+		results = append(results, result)
+	}
+
+	return results, matched
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"math"
 	"slices"
 	"testing"
 
@@ -203,6 +204,7 @@ func TestValidation(t *testing.T) {
 				Name:    "parenthesis work also across filters",
 				Filters: []string{},
 				Action:  "done",
+				Confirm: true,
 			},
 			want: []string{"rule has no filters"},
 		},
@@ -213,7 +215,8 @@ func TestValidation(t *testing.T) {
 					`(.reason == "test" or .id == "2")`,
 					`(.unread == true or .id == "1")`,
 				},
-				Action: "done",
+				Action:  "done",
+				Confirm: true,
 			},
 			want: []string{},
 		},
@@ -223,7 +226,8 @@ func TestValidation(t *testing.T) {
 					`(.reason == "test" or .id == "2")`,
 					`(.unread == true or .id == "1")`,
 				},
-				Action: "done",
+				Action:  "done",
+				Confirm: true,
 			},
 			want: []string{},
 		},
@@ -241,3 +245,201 @@ func TestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		r    Rule
+		want []string
+	}{
+		{
+			name: "actions list",
+			r: Rule{
+				Filters: []string{`.unread`},
+				Actions: []RuleAction{{Name: "tag", Args: []string{"+a"}}, {Name: "read"}},
+				Confirm: true,
+			},
+		},
+		{
+			name: "action and actions list",
+			r: Rule{
+				Filters: []string{`.unread`},
+				Action:  "hide",
+				Actions: []RuleAction{{Name: "read"}},
+				Confirm: true,
+			},
+		},
+		{
+			name: "invalid action in the list",
+			r: Rule{
+				Filters: []string{`.unread`},
+				Actions: []RuleAction{{Name: "read"}, {Name: "nope"}},
+				Confirm: true,
+			},
+			want: []string{`invalid rule action: "nope"`},
+		},
+		{
+			name: "empty action in the list",
+			r: Rule{
+				Filters: []string{`.unread`},
+				Actions: []RuleAction{{Args: []string{"a"}}},
+			},
+			want: []string{"rule action is empty"},
+		},
+		{
+			name: "remote action without confirm",
+			r: Rule{
+				Filters: []string{`.unread`},
+				Actions: []RuleAction{{Name: "tag", Args: []string{"+a"}}, {Name: "done"}},
+			},
+			want: []string{
+				"rule action \"done\" changes GitHub: add `confirm: true` to the rule, " +
+					"or run 'gh-not config migrate' to confirm all such rules",
+			},
+		},
+		{
+			name: "negative max_per_run",
+			r: Rule{
+				Filters:   []string{`.unread`},
+				Action:    "hide",
+				MaxPerRun: -1,
+			},
+			want: []string{"max_per_run cannot be negative"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := test.r.Validate()
+
+			if !slices.Equal(violations, test.want) {
+				t.Fatalf("want %#v, but got %#v", test.want, violations)
+			}
+		})
+	}
+}
+
+func TestActionList(t *testing.T) {
+	t.Parallel()
+
+	r := Rule{
+		Action:  "tag",
+		Args:    []string{"+a"},
+		Actions: []RuleAction{{Name: "read"}, {Name: "tag", Args: []string{"-b"}}},
+	}
+
+	want := []RuleAction{
+		{Name: "tag", Args: []string{"+a"}},
+		{Name: "read"},
+		{Name: "tag", Args: []string{"-b"}},
+	}
+
+	got := r.ActionList()
+
+	if !slices.EqualFunc(got, want, func(a, b RuleAction) bool {
+		return a.Name == b.Name && slices.Equal(a.Args, b.Args)
+	}) {
+		t.Fatalf("want %#v, but got %#v", want, got)
+	}
+}
+
+func TestEnabledAndContinues(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+
+	tests := []struct {
+		name          string
+		r             Rule
+		wantEnabled   bool
+		wantContinues bool
+	}{
+		{"defaults", Rule{}, true, true},
+		{"explicitly on", Rule{Enabled: &yes, Continue: &yes}, true, true},
+		{"explicitly off", Rule{Enabled: &no, Continue: &no}, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := test.r.IsEnabled(); got != test.wantEnabled {
+				t.Errorf("want enabled %v, got %v", test.wantEnabled, got)
+			}
+
+			if got := test.r.Continues(); got != test.wantContinues {
+				t.Errorf("want continues %v, got %v", test.wantContinues, got)
+			}
+		})
+	}
+}
+
+func TestSortRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Name: "a"},
+		{Name: "b", Priority: 10},
+		{Name: "c", Priority: -1},
+		{Name: "d"},
+		{Name: "e", Priority: 10},
+		{Name: "f", Priority: math.MinInt},
+		{Name: "g", Priority: math.MaxInt},
+	}
+
+	got := []string{}
+	for _, r := range SortRules(rules) {
+		got = append(got, r.Name)
+	}
+
+	want := []string{"g", "b", "e", "a", "d", "c", "f"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("want %#v, but got %#v", want, got)
+	}
+
+	if rules[0].Name != "a" {
+		t.Fatalf("original rules were modified: %#v", rules)
+	}
+
+	if got, want := RuleOrder(rules), []int{6, 1, 4, 0, 3, 2, 5}; !slices.Equal(got, want) {
+		t.Fatalf("want order %#v, but got %#v", want, got)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	r := Rule{
+		Filters: []string{
+			`.reason == "test"`,
+			`.unread == true`,
+			`!!!`,
+		},
+	}
+
+	results, matched := r.Explain(&notifications.Notification{Reason: "test"})
+
+	if matched {
+		t.Fatal("want no match, got a match")
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("want 3 results, got %d", len(results))
+	}
+
+	if !results[0].Matched || results[0].Err != nil {
+		t.Errorf("want first filter to match, got %#v", results[0])
+	}
+
+	if results[1].Matched || results[1].Err != nil {
+		t.Errorf("want second filter to not match, got %#v", results[1])
+	}
+
+	if results[2].Matched || results[2].Err == nil {
+		t.Errorf("want third filter to fail, got %#v", results[2])
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+// RuleTest is a sample notification with the expected result of a Rule's
+// filters on it.
+type RuleTest struct {
+	Name string `mapstructure:"name" yaml:"name,omitempty"`
+
+	// Notification is the sample notification, in the same format as the
+	// JSON output.
+	Notification map[string]any `mapstructure:"notification" yaml:"notification,omitempty"`
+
+	// Match is the expected result of the rule's filters.
+	Match bool `mapstructure:"match" yaml:"match,omitempty"`
+}
+
+// Run runs the test against the rule's filters and returns a description of
+// the failure, if any.
+// A filter that fails, e.g. `is_mine` without a GitHub client, fails the test
+// whatever the expected result.
+func (t RuleTest) Run(r Rule) string {
+	n, err := t.notification()
+	if err != nil {
+		return err.Error()
+	}
+
+	results, matched := r.Explain(n)
+
+	failed := []string{}
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("filter %s failed: %v", result.Filter, result.Err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return strings.Join(failed, ", ")
+	}
+
+	if matched == t.Match {
+		return ""
+	}
+
+	if t.Match {
+		diverged := []string{}
+
+		for _, result := range results {
+			if !result.Matched {
+				diverged = append(diverged, fmt.Sprintf("filter %s did not match", result.Filter))
+			}
+		}
+
+		return "want a match, got none: " + strings.Join(diverged, ", ")
+	}
+
+	return "want no match, got one: all filters matched"
+}
+
+func (t RuleTest) notification() (*notifications.Notification, error) {
+	marshaled, err := json.Marshal(t.Notification)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test notification: %w", err)
+	}
+
+	n := &notifications.Notification{}
+	if err := json.Unmarshal(marshaled, n); err != nil {
+		return nil, fmt.Errorf("invalid test notification: %w", err)
+	}
+
+	return n, nil
+}
+
+// TestFailure describes a failing RuleTest.
+type TestFailure struct {
+	RuleIndex int
+	Rule      string
+	TestIndex int
+	Test      string
+	Reason    string
+}
+
+func (f TestFailure) String() string {
+	return fmt.Sprintf("rule %q (index %d), test %q (index %d): %s",
+		f.Rule, f.RuleIndex, f.Test, f.TestIndex, f.Reason)
+}
+
+// TestRules runs all the rules' tests. It returns the number of tests run and
+// the failures.
+func (c *Config) TestRules() (int, []TestFailure) {
+	count := 0
+	failures := []TestFailure{}
+
+	for i, rule := range c.Data.Rules {
+		for j, test := range rule.Tests {
+			count++
+
+			if reason := test.Run(rule); reason != "" {
+				failures = append(failures, TestFailure{
+					RuleIndex: i,
+					Rule:      rule.Name,
+					TestIndex: j,
+					Test:      test.Name,
+					Reason:    reason,
+				})
+			}
+		}
+	}
+
+	return count, failures
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleTestRun(t *testing.T) {
+	t.Parallel()
+
+	r := Rule{
+		Filters: []string{
+			`.author.type == "Bot"`,
+			`.unread`,
+		},
+	}
+
+	failing := Rule{
+		Filters: []string{
+			`.author.type | error`,
+			`.unread`,
+		},
+	}
+
+	tests := []struct {
+		name string
+		rule *Rule
+		test RuleTest
+		want string
+	}{
+		{
+			name: "expected match",
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "Bot"}, "unread": true},
+				Match:        true,
+			},
+		},
+		{
+			name: "expected no match",
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "User"}, "unread": true},
+			},
+		},
+		{
+			name: "unexpected no match",
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "Bot"}},
+				Match:        true,
+			},
+			want: "filter .unread did not match",
+		},
+		{
+			name: "unexpected match",
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "Bot"}, "unread": true},
+			},
+			want: "all filters matched",
+		},
+		{
+			name: "failing filter with an expected no match",
+			rule: &failing,
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "Bot"}},
+			},
+			want: "filter .author.type | error failed",
+		},
+		{
+			name: "failing filter with an expected match",
+			rule: &failing,
+			test: RuleTest{
+				Notification: map[string]any{"author": map[string]any{"type": "Bot"}, "unread": true},
+				Match:        true,
+			},
+			want: "filter .author.type | error failed",
+		},
+		{
+			name: "invalid notification",
+			test: RuleTest{
+				Notification: map[string]any{"unread": "yes"},
+			},
+			want: "invalid test notification",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := r
+			if test.rule != nil {
+				rule = *test.rule
+			}
+
+			got := test.test.Run(rule)
+
+			if test.want == "" && got != "" {
+				t.Fatalf("want no failure, got %q", got)
+			}
+
+			if !strings.Contains(got, test.want) {
+				t.Fatalf("want failure containing %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTestRules(t *testing.T) {
+	t.Parallel()
+
+	c := &Config{Data: &Data{Rules: []Rule{
+		{
+			Name:    "first",
+			Filters: []string{`.unread`},
+			Tests: []RuleTest{
+				{Name: "ok", Notification: map[string]any{"unread": true}, Match: true},
+				{Name: "ko", Notification: map[string]any{"unread": true}},
+			},
+		},
+		{
+			Name:    "second",
+			Filters: []string{`.unread`},
+			Tests: []RuleTest{
+				{Name: "ko", Notification: map[string]any{"unread": false}, Match: true},
+			},
+		},
+	}}}
+
+	count, failures := c.TestRules()
+
+	if count != 3 {
+		t.Fatalf("want 3 tests, got %d", count)
+	}
+
+	if len(failures) != 2 {
+		t.Fatalf("want 2 failures, got %#v", failures)
+	}
+
+	if failures[0].Rule != "first" || failures[0].TestIndex != 1 {
+		t.Errorf("unexpected first failure %#v", failures[0])
+	}
+
+	if failures[1].Rule != "second" || failures[1].RuleIndex != 1 {
+		t.Errorf("unexpected second failure %#v", failures[1])
+	}
+}
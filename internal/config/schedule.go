@@ -0,0 +1,187 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidDay   = errors.New("invalid day")
+	errInvalidHours = errors.New("invalid hours, expected HH:MM-HH:MM")
+)
+
+//nolint:gochecknoglobals // This map is used as a constant.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Schedule restricts when a Rule is applied.
+// A rule is applied if the current day is in Days and the current time is in
+// any of the Hours. Empty Days or Hours match any day or time.
+//
+//	rules:
+//	  - name: read bots outside working hours
+//	    action: read
+//	    confirm: true
+//	    filters:
+//	      - .author.type == "Bot"
+//	    schedule:
+//	      days: [mon, tue, wed, thu, fri]
+//	      hours: ["18:00-09:00"]
+//	      timezone: Europe/Paris
+type Schedule struct {
+	// Days is a list of week days, e.g. `mon` or `monday`.
+	Days []string `mapstructure:"days" yaml:"days,omitempty"`
+
+	// Hours is a list of time ranges, in the `HH:MM-HH:MM` format.
+	// The start is inclusive and the end is exclusive. A range can wrap
+	// around midnight, e.g. `22:00-06:00`.
+	Hours []string `mapstructure:"hours" yaml:"hours,omitempty"`
+
+	// Timezone is the IANA timezone the days and hours are in, e.g.
+	// `Europe/Paris`. Defaults to the local timezone.
+	Timezone string `mapstructure:"timezone" yaml:"timezone,omitempty"`
+}
+
+// Active returns true if the schedule includes the time t.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func (s *Schedule) Active(t time.Time) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+
+	if s.Timezone != "" {
+		location, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid timezone: %w", err)
+		}
+
+		t = t.In(location)
+	}
+
+	if len(s.Days) > 0 {
+		found := false
+
+		for _, day := range s.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return false, fmt.Errorf("%w: %q", errInvalidDay, day)
+			}
+
+			found = found || weekday == t.Weekday()
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	if len(s.Hours) == 0 {
+		return true, nil
+	}
+
+	minutes := t.Hour()*60 + t.Minute()
+
+	for _, hours := range s.Hours {
+		start, end, err := parseHours(hours)
+		if err != nil {
+			return false, err
+		}
+
+		if start <= end && minutes >= start && minutes < end {
+			return true, nil
+		}
+
+		if start > end && (minutes >= start || minutes < end) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Validate returns the schedule's violations.
+func (s *Schedule) Validate() []string {
+	if s == nil {
+		return nil
+	}
+
+	violations := []string{}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		violations = append(violations, fmt.Sprintf("invalid schedule: invalid timezone: %v", err))
+	}
+
+	for _, day := range s.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			violations = append(violations, fmt.Sprintf("invalid schedule: %v: %q", errInvalidDay, day))
+		}
+	}
+
+	for _, hours := range s.Hours {
+		if _, _, err := parseHours(hours); err != nil {
+			violations = append(violations, fmt.Sprintf("invalid schedule: %v", err))
+		}
+	}
+
+	return violations
+}
+
+// parseHours parses a `HH:MM-HH:MM` range into minutes since midnight.
+func parseHours(hours string) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(hours, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q", errInvalidHours, hours)
+	}
+
+	start, err := parseClock(startStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", errInvalidHours, hours)
+	}
+
+	end, err := parseClock(endStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", errInvalidHours, hours)
+	}
+
+	if start == end {
+		return 0, 0, fmt.Errorf("%w: %q is empty", errInvalidHours, hours)
+	}
+
+	return start, end, nil
+}
+
+// parseClock parses `HH:MM` or `HH` into minutes since midnight.
+// `24:00` is accepted as the end of the day.
+func parseClock(clock string) (int, error) {
+	hourStr, minuteStr, hasMinutes := strings.Cut(strings.TrimSpace(clock), ":")
+
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hour: %w", err)
+	}
+
+	minute := 0
+	if hasMinutes {
+		if minute, err = strconv.Atoi(minuteStr); err != nil {
+			return 0, fmt.Errorf("invalid minute: %w", err)
+		}
+	}
+
+	total := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || total > 24*60 {
+		return 0, errInvalidHours
+	}
+
+	return total, nil
+}
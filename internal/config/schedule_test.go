@@ -0,0 +1,99 @@
+package config
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScheduleActive(t *testing.T) {
+	t.Parallel()
+
+	// 2024-01-05 is a Friday.
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 5, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		schedule *Schedule
+		t        time.Time
+		want     bool
+	}{
+		{"nil schedule", nil, friday(12, 0), true},
+		{"empty schedule", &Schedule{}, friday(12, 0), true},
+		{"matching day", &Schedule{Days: []string{"mon", "Fri"}}, friday(12, 0), true},
+		{"matching full day", &Schedule{Days: []string{"friday"}}, friday(12, 0), true},
+		{"other day", &Schedule{Days: []string{"sat", "sun"}}, friday(12, 0), false},
+		{"in hours", &Schedule{Hours: []string{"09:00-17:00"}}, friday(9, 0), true},
+		{"end is exclusive", &Schedule{Hours: []string{"09:00-17:00"}}, friday(17, 0), false},
+		{"short format", &Schedule{Hours: []string{"9-17"}}, friday(16, 59), true},
+		{"any range", &Schedule{Hours: []string{"08:00-09:00", "12:00-13:00"}}, friday(12, 30), true},
+		{"overnight, evening", &Schedule{Hours: []string{"18:00-09:00"}}, friday(22, 0), true},
+		{"overnight, morning", &Schedule{Hours: []string{"18:00-09:00"}}, friday(8, 59), true},
+		{"overnight, day", &Schedule{Hours: []string{"18:00-09:00"}}, friday(12, 0), false},
+		{"day and hours", &Schedule{Days: []string{"fri"}, Hours: []string{"12:00-13:00"}}, friday(12, 0), true},
+		{
+			name:     "timezone changes the day",
+			schedule: &Schedule{Days: []string{"sat"}, Timezone: "Asia/Tokyo"},
+			t:        friday(20, 0),
+			want:     true,
+		},
+		{
+			name:     "timezone changes the hours",
+			schedule: &Schedule{Hours: []string{"09:00-17:00"}, Timezone: "America/New_York"},
+			t:        friday(12, 0),
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := test.schedule.Active(test.t)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Fatalf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule *Schedule
+		want     []string
+	}{
+		{"nil schedule", nil, nil},
+		{"valid", &Schedule{Days: []string{"mon"}, Hours: []string{"9-17", "22:00-24:00"}, Timezone: "UTC"}, []string{}},
+		{
+			name:     "invalid values",
+			schedule: &Schedule{Days: []string{"mon", "someday"}, Hours: []string{"9", "25-26", "10-10"}, Timezone: "Nowhere"},
+			want: []string{
+				`invalid schedule: invalid timezone: unknown time zone Nowhere`,
+				`invalid schedule: invalid day: "someday"`,
+				`invalid schedule: invalid hours, expected HH:MM-HH:MM: "9"`,
+				`invalid schedule: invalid hours, expected HH:MM-HH:MM: "25-26"`,
+				`invalid schedule: invalid hours, expected HH:MM-HH:MM: "10-10" is empty`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.schedule.Validate()
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/nobe4/gh-not/internal/actions"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+const schemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns the JSON Schema of the configuration file, generated from
+// Data's `mapstructure` tags.
+// Unknown keys are forbidden, the actions and the keymap's modes and actions
+// are restricted to the known ones.
+func Schema() map[string]any {
+	schema := typeSchema(reflect.TypeFor[Data]())
+	schema["$schema"] = schemaVersion
+	schema["title"] = "gh-not configuration"
+
+	return schema
+}
+
+// typeSchema returns the JSON Schema of a type.
+//
+//revive:disable:cyclomatic // The switch is easier to read as a whole.
+func typeSchema(t reflect.Type) map[string]any {
+	switch t {
+	case reflect.TypeFor[Keymap]():
+		return keymapSchema()
+	case reflect.TypeFor[KeyBinding]():
+		return map[string]any{"type": "array", "items": map[string]any{"type": "string"}}
+	case reflect.TypeFor[Column]():
+		return map[string]any{"anyOf": []any{
+			map[string]any{"type": "string", "enum": notifications.BuiltinColumnNames()},
+			structSchema(t),
+		}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		properties[name] = fieldSchema(t, field)
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// fieldSchema returns the JSON Schema of a struct field, restricting the fields
+// holding action names to the known actions.
+func fieldSchema(t reflect.Type, field reflect.StructField) map[string]any {
+	isAction := (t == reflect.TypeFor[Rule]() && field.Name == "Action") ||
+		(t == reflect.TypeFor[RuleAction]() && field.Name == "Name")
+
+	if isAction {
+		return map[string]any{"type": "string", "enum": actionNames()}
+	}
+
+	return typeSchema(field.Type)
+}
+
+// keymapSchema returns the JSON Schema of the keymap, with the modes and
+// actions found in the Defaults.
+func keymapSchema() map[string]any {
+	binding := typeSchema(reflect.TypeFor[KeyBinding]())
+	bindings := map[string]map[string]any{}
+
+	for key := range Defaults {
+		mode, action, ok := strings.Cut(strings.TrimPrefix(key, "keymap."), ".")
+		if !ok || !strings.HasPrefix(key, "keymap.") {
+			continue
+		}
+
+		if _, ok := bindings[mode]; !ok {
+			bindings[mode] = map[string]any{}
+		}
+
+		bindings[mode][action] = binding
+	}
+
+	modes := map[string]any{}
+	for mode, properties := range bindings {
+		modes[mode] = map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           modes,
+		"additionalProperties": false,
+	}
+}
+
+func actionNames() []string {
+	names := []string{}
+	for name := range actions.GetMap(nil) {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
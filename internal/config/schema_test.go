@@ -0,0 +1,61 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+//nolint:forcetypeassert // The schema's structure is known.
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	properties := Schema()["properties"].(map[string]any)
+
+	t.Run("forbids unknown keys", func(t *testing.T) {
+		t.Parallel()
+
+		cache := properties["cache"].(map[string]any)
+		if cache["additionalProperties"] != false {
+			t.Errorf("want no additional properties, got %v", cache["additionalProperties"])
+		}
+
+		if _, ok := cache["properties"].(map[string]any)["ttl_in_hours"]; !ok {
+			t.Errorf("want ttl_in_hours in %v", cache["properties"])
+		}
+	})
+
+	t.Run("restricts the actions", func(t *testing.T) {
+		t.Parallel()
+
+		rule := properties["rules"].(map[string]any)["items"].(map[string]any)["properties"].(map[string]any)
+
+		action := rule["action"].(map[string]any)["enum"].([]string)
+		if !slices.Contains(action, "done") || slices.Contains(action, "nope") {
+			t.Errorf("want the known actions, got %v", action)
+		}
+
+		ruleAction := rule["actions"].(map[string]any)["items"].(map[string]any)["properties"].(map[string]any)
+		if !slices.Equal(ruleAction["name"].(map[string]any)["enum"].([]string), action) {
+			t.Errorf("want the known actions, got %v", ruleAction["name"])
+		}
+
+		if _, ok := rule["origin"]; ok {
+			t.Error("want no origin")
+		}
+	})
+
+	t.Run("restricts the keymap", func(t *testing.T) {
+		t.Parallel()
+
+		modes := properties["keymap"].(map[string]any)["properties"].(map[string]any)
+
+		normal := modes["normal"].(map[string]any)["properties"].(map[string]any)
+		if _, ok := normal["quit"]; !ok {
+			t.Errorf("want quit in %v", normal)
+		}
+
+		if _, ok := modes["nope"]; ok {
+			t.Errorf("want no unknown mode in %v", modes)
+		}
+	})
+}
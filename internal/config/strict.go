@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/nobe4/dent.go"
+	"github.com/spf13/viper"
+
+	"github.com/nobe4/gh-not/internal/actions"
+)
+
+var errStrictValidation = errors.New("invalid config")
+
+// ValidateStrict checks the config further than New does: it fails on unknown
+// keys, invalid action arguments, unknown keymap modes and actions, and keys
+// bound to several actions.
+func (c *Config) ValidateStrict() error {
+	violations := c.unknownKeys()
+	violations = append(violations, c.validateArgs()...)
+	violations = append(violations, c.Data.Keymap.Validate()...)
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n%s", errStrictValidation, dent.IndentString(strings.Join(violations, "\n"), "  - "))
+}
+
+// unknownKeys decodes the config again, listing the keys that don't map to any
+// field.
+func (c *Config) unknownKeys() []string {
+	var (
+		data     Data
+		metadata mapstructure.Metadata
+	)
+
+	err := c.viper.Unmarshal(&data, decodeHook(), viper.DecoderConfigOption(func(dc *mapstructure.DecoderConfig) {
+		dc.Metadata = &metadata
+	}))
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	violations := make([]string, 0, len(metadata.Unused))
+	for _, key := range metadata.Unused {
+		violations = append(violations, "unknown key: "+key)
+	}
+
+	slices.Sort(violations)
+
+	return violations
+}
+
+// validateArgs checks the arguments passed to each rule's actions.
+func (c *Config) validateArgs() []string {
+	violations := []string{}
+	actionsMap := actions.GetMap(nil)
+
+	for i, rule := range c.Data.Rules {
+		origin := rule.Origin.String()
+		if rule.Origin.File == "" {
+			origin = fmt.Sprintf("index %d", i)
+		}
+
+		for _, action := range rule.ActionList() {
+			if err := actionsMap.ValidateArgs(action.Name, action.Args); err != nil {
+				violations = append(violations,
+					fmt.Sprintf("rule %q (%s): invalid arguments for %s: %v", rule.Name, origin, action.Name, err))
+			}
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name: "valid",
+			config: `
+cache:
+  ttl_in_hours: 1
+rules:
+  - filters: [.unread]
+    action: tag
+    args: [+a]
+`,
+		},
+		{
+			name: "unknown keys",
+			config: `
+cache:
+  ttl_in_hour: 1
+views: {}
+profiles:
+  work:
+    view:
+      height: 1
+rules:
+  - filters: [.unread]
+    action: hide
+    arg: [a]
+`,
+			want: []string{
+				"unknown key: cache.ttl_in_hour",
+				"unknown key: profiles[work].view",
+				"unknown key: rules[0].arg",
+			},
+		},
+		{
+			name: "invalid arguments",
+			config: `
+rules:
+  - name: tag
+    filters: [.unread]
+    action: tag
+  - name: assign
+    filters: [.unread]
+    confirm: true
+    actions:
+      - name: assign
+        args: [""]
+`,
+			want: []string{
+				`rule "tag" (index 0 in `,
+				"invalid arguments for tag: no tags provided",
+				`rule "assign" (index 1 in `,
+				`invalid arguments for assign: invalid assignee: ""`,
+			},
+		},
+		{
+			name: "keymap",
+			config: `
+keymap:
+  normal:
+    select all: [q]
+`,
+			want: []string{`key "q" is bound to both "quit" and "select all" in mode "normal"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := New(writeConfig(t, test.config), "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.ValidateStrict()
+			if len(test.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errStrictValidation) {
+				t.Fatalf("want %v, got %v", errStrictValidation, err)
+			}
+
+			for _, want := range test.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("want %q in %q", want, err)
+				}
+			}
+		})
+	}
+}
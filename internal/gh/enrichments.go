@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/text"
 
 	"github.com/nobe4/gh-not/internal/notifications"
 )
 
+// commentExcerptWidth is the maximum width of the latest comment's excerpt
+// kept in the cache.
+const commentExcerptWidth = 280
+
 type ThreadExtra struct {
 	User           notifications.User   `json:"user"`
 	State          string               `json:"state"`
@@ -29,7 +36,7 @@ func (c *Client) Enrich(n *notifications.Notification) error {
 		return err
 	}
 
-	lastCommentor, err := c.getLastCommentor(n)
+	latestComment, err := c.getLatestComment(n)
 	if err != nil {
 		return err
 	}
@@ -41,20 +48,33 @@ func (c *Client) Enrich(n *notifications.Notification) error {
 	n.Reviewers = threadExtra.Reviewers
 	n.ReviewersTeams = threadExtra.ReviewersTeams
 	n.MergedBy = threadExtra.MergedBy
-	n.LatestCommentor = lastCommentor
+	n.LatestCommentor = latestComment.User
+	n.LatestComment = excerpt(latestComment.Body)
 	n.Meta.Enriched = true
 
 	return nil
 }
 
 func (c *Client) getJSON(url string, v any) error {
+	_, err := c.getJSONPage(url, v)
+
+	return err
+}
+
+// getJSONPage is like getJSON, and also returns the link to the next page, if
+// any.
+func (c *Client) getJSONPage(url string, v any) (string, error) {
 	resp, err := c.API.Request(http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get %s: %w", url, err)
+		return "", fmt.Errorf("failed to get %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
-	return json.NewDecoder(resp.Body).Decode(v) //nolint:wrapcheck // This is wrapped by the caller
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return "", err //nolint:wrapcheck // This is wrapped by the caller
+	}
+
+	return nextPageLink(&resp.Header), nil
 }
 
 func (c *Client) getThreadExtra(n *notifications.Notification) (ThreadExtra, error) {
@@ -72,19 +92,27 @@ func (c *Client) getThreadExtra(n *notifications.Notification) (ThreadExtra, err
 	return extra, nil
 }
 
-func (c *Client) getLastCommentor(n *notifications.Notification) (notifications.User, error) {
+type comment struct {
+	User notifications.User `json:"user"`
+	Body string             `json:"body"`
+}
+
+func (c *Client) getLatestComment(n *notifications.Notification) (comment, error) {
 	if n.Subject.LatestCommentURL == "" {
-		return notifications.User{}, nil
+		return comment{}, nil
 	}
 
-	slog.Debug("getting the last commentor", "id", n.ID, "url", n.Subject.LatestCommentURL)
+	slog.Debug("getting the latest comment", "id", n.ID, "url", n.Subject.LatestCommentURL)
 
-	comment := struct {
-		User notifications.User `json:"user"`
-	}{}
-	if err := c.getJSON(n.Subject.LatestCommentURL, &comment); err != nil {
-		return notifications.User{}, fmt.Errorf("failed to get last commentor: %w", err)
+	latest := comment{}
+	if err := c.getJSON(n.Subject.LatestCommentURL, &latest); err != nil {
+		return comment{}, fmt.Errorf("failed to get latest comment: %w", err)
 	}
 
-	return comment.User, nil
+	return latest, nil
+}
+
+// excerpt returns the beginning of the body, on a single line.
+func excerpt(body string) string {
+	return text.Truncate(commentExcerptWidth, strings.Join(strings.Fields(body), " "))
 }
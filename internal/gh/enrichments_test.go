@@ -1,6 +1,8 @@
 package gh
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -41,6 +43,7 @@ func TestEnrichLeavesNotificationUnchangedOnFailure(t *testing.T) {
 		n.ReviewersTeams = []notifications.Team{{Name: "old-team", ID: 7}}
 		n.MergedBy = oldUser("old-merger")
 		n.LatestCommentor = oldUser("old-commentor")
+		n.LatestComment = "old comment"
 
 		return n
 	}
@@ -84,3 +87,48 @@ func TestEnrichLeavesNotificationUnchangedOnFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrichLatestComment(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("word ", 100)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "single line", body: "LGTM", want: "LGTM"},
+		{name: "multiple lines", body: "Looks good.\r\n\r\n- one\n- two", want: "Looks good. - one - two"},
+		{name: "long", body: long, want: long[:commentExcerptWidth-3] + "..."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(map[string]any{"user": map[string]string{"login": "commentor"}, "body": test.body})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, m := mockClient([]mock.Call{
+				{URL: mockSubjectURL(0), Response: &http.Response{Body: io.NopCloser(strings.NewReader(`{}`))}},
+				{URL: mockLatestCommentURL(0), Response: &http.Response{Body: io.NopCloser(bytes.NewReader(body))}},
+			})
+
+			n := mockNotification(0)
+			if err := client.Enrich(n); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if n.LatestCommentor.Login != "commentor" || n.LatestComment != test.want {
+				t.Errorf("want %q by commentor, got %q by %q", test.want, n.LatestComment, n.LatestCommentor.Login)
+			}
+
+			if err := m.Done(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
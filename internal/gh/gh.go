@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"sync"
 
 	ghapi "github.com/cli/go-gh/v2/pkg/api"
 
@@ -28,6 +29,10 @@ type Client struct {
 	maxRetry int
 	maxPage  int
 	path     string
+
+	mu    sync.Mutex
+	user  string
+	teams map[string][]string
 }
 
 type Endpoint struct {
@@ -35,22 +40,22 @@ type Endpoint struct {
 	// By default, only the unread notifications are fetched.
 	// This maps to `?all=true|false` in the GitHub API.
 	// See https://docs.github.com/en/rest/activity/notifications?apiVersion=2022-11-28#list-notifications-for-the-authenticated-user
-	All bool `mapstructure:"all"`
+	All bool `mapstructure:"all" yaml:"all"`
 
 	// The maximum number of retries to fetch notifications.
 	// The Notifications API is notably flaky, retrying HTTP requests is
 	// definitely needed.
-	MaxRetry int `mapstructure:"max_retry"`
+	MaxRetry int `mapstructure:"max_retry" yaml:"max_retry"`
 
 	// The number of notification pages to fetch.
 	// This will cap the `?page=X` parameter in the GitHub API.
 	// See https://docs.github.com/en/rest/activity/notifications?apiVersion=2022-11-28#list-notifications-for-the-authenticated-user
-	MaxPage int `mapstructure:"max_page"`
+	MaxPage int `mapstructure:"max_page" yaml:"max_page"`
 
 	// The number of notifications to fetch per page.
 	// This maps to `?per_page=X` in the GitHub API.
 	// See https://docs.github.com/en/rest/activity/notifications?apiVersion=2022-11-28#list-notifications-for-the-authenticated-user
-	PerPage int `mapstructure:"per_page"`
+	PerPage int `mapstructure:"per_page" yaml:"per_page"`
 }
 
 func NewClient(a api.Requestor, c cache.RefreshReadWriter, conf Endpoint) *Client {
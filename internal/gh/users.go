@@ -0,0 +1,74 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+var errInvalidTeam = errors.New("invalid team, expected org/team")
+
+// User returns the authenticated user's login.
+// The result is cached for the lifetime of the client.
+func (c *Client) User() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.user != "" {
+		return c.user, nil
+	}
+
+	user := notifications.User{}
+	if err := c.getJSON("user", &user); err != nil {
+		return "", fmt.Errorf("failed to get the authenticated user: %w", err)
+	}
+
+	c.user = user.Login
+
+	return c.user, nil
+}
+
+// TeamMembers returns the logins of a team's members, the team is in the
+// `org/team` format.
+// It goes through all the pages of members.
+// The results are cached for the lifetime of the client.
+func (c *Client) TeamMembers(team string) ([]string, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok || org == "" || slug == "" {
+		return nil, fmt.Errorf("%w: %q", errInvalidTeam, team)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if members, ok := c.teams[team]; ok {
+		return members, nil
+	}
+
+	members := []string{}
+	endpoint := fmt.Sprintf("orgs/%s/teams/%s/members?per_page=100", org, slug)
+
+	for endpoint != "" {
+		users := []notifications.User{}
+
+		var err error
+
+		if endpoint, err = c.getJSONPage(endpoint, &users); err != nil {
+			return nil, fmt.Errorf("failed to get the members of %s: %w", team, err)
+		}
+
+		for _, u := range users {
+			members = append(members, u.Login)
+		}
+	}
+
+	if c.teams == nil {
+		c.teams = map[string][]string{}
+	}
+
+	c.teams[team] = members
+
+	return members, nil
+}
@@ -0,0 +1,141 @@
+package gh
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nobe4/gh-not/internal/api/mock"
+)
+
+func mockBody(body string) *http.Response {
+	return &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("caches the user", func(t *testing.T) {
+		t.Parallel()
+
+		requestor := &mock.Mock{Calls: []mock.Call{
+			{URL: "user", Response: mockBody(`{"login": "me"}`)},
+		}}
+		client := NewClient(requestor, nil, Endpoint{})
+
+		for range 2 {
+			got, err := client.User()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != "me" {
+				t.Fatalf("want me, got %q", got)
+			}
+		}
+
+		if err := requestor.Done(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		t.Parallel()
+
+		requestor := &mock.Mock{Calls: []mock.Call{{URL: "user", Error: errSample}}}
+		client := NewClient(requestor, nil, Endpoint{})
+
+		if _, err := client.User(); !errors.Is(err, errSample) {
+			t.Fatalf("want %v, got %v", errSample, err)
+		}
+	})
+}
+
+func TestTeamMembers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("caches the members", func(t *testing.T) {
+		t.Parallel()
+
+		requestor := &mock.Mock{Calls: []mock.Call{
+			{
+				URL:      "orgs/org/teams/team/members?per_page=100",
+				Response: mockBody(`[{"login": "a"}, {"login": "b"}]`),
+			},
+		}}
+		client := NewClient(requestor, nil, Endpoint{})
+
+		for range 2 {
+			got, err := client.TeamMembers("org/team")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, []string{"a", "b"}) {
+				t.Fatalf("want [a b], got %#v", got)
+			}
+		}
+
+		if err := requestor.Done(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("goes through the pages", func(t *testing.T) {
+		t.Parallel()
+
+		first := mockBody(`[{"login": "a"}]`)
+		first.Header = http.Header{"Link": []string{`<https://api.github.com/next>; rel="next"`}}
+
+		requestor := &mock.Mock{Calls: []mock.Call{
+			{URL: "orgs/org/teams/team/members?per_page=100", Response: first},
+			{URL: "https://api.github.com/next", Response: mockBody(`[{"login": "b"}]`)},
+		}}
+		client := NewClient(requestor, nil, Endpoint{})
+
+		got, err := client.TeamMembers("org/team")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !slices.Equal(got, []string{"a", "b"}) {
+			t.Fatalf("want [a b], got %#v", got)
+		}
+
+		if err := requestor.Done(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("fails on a page", func(t *testing.T) {
+		t.Parallel()
+
+		first := mockBody(`[{"login": "a"}]`)
+		first.Header = http.Header{"Link": []string{`<https://api.github.com/next>; rel="next"`}}
+
+		requestor := &mock.Mock{Calls: []mock.Call{
+			{URL: "orgs/org/teams/team/members?per_page=100", Response: first},
+			{URL: "https://api.github.com/next", Error: errSample},
+		}}
+		client := NewClient(requestor, nil, Endpoint{})
+
+		if _, err := client.TeamMembers("org/team"); !errors.Is(err, errSample) {
+			t.Fatalf("want %v, got %v", errSample, err)
+		}
+	})
+
+	t.Run("invalid team", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(&mock.Mock{}, nil, Endpoint{})
+
+		for _, team := range []string{"", "org", "org/", "/team"} {
+			if _, err := client.TeamMembers(team); !errors.Is(err, errInvalidTeam) {
+				t.Errorf("%q: want %v, got %v", team, errInvalidTeam, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package jq
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/itchyny/gojq"
+)
+
+// codeCacheSize is the number of compiled filters kept by a Library.
+// It's enough for the config's filters, while the filters typed in the REPL
+// don't grow the cache forever.
+const codeCacheSize = 256
+
+// codeCache is a least recently used cache of compiled filters.
+// The zero value is ready to use.
+type codeCache struct {
+	mu sync.Mutex
+
+	// order holds the entries, the most recently used first.
+	order   list.List
+	entries map[string]*list.Element
+}
+
+type codeEntry struct {
+	filter string
+	code   *gojq.Code
+}
+
+func (c *codeCache) get(filter string) (*gojq.Code, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[filter]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	//nolint:forcetypeassert // Only codeEntry are stored.
+	return e.Value.(codeEntry).code, true
+}
+
+func (c *codeCache) add(filter string, code *gojq.Code) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[string]*list.Element{}
+	}
+
+	if e, ok := c.entries[filter]; ok {
+		e.Value = codeEntry{filter: filter, code: code}
+		c.order.MoveToFront(e)
+
+		return
+	}
+
+	c.entries[filter] = c.order.PushFront(codeEntry{filter: filter, code: code})
+
+	if c.order.Len() > codeCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		//nolint:forcetypeassert // Only codeEntry are stored.
+		delete(c.entries, oldest.Value.(codeEntry).filter)
+	}
+}
+
+func (c *codeCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,66 @@
+package jq
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/itchyny/gojq"
+)
+
+func TestCodeCache(t *testing.T) {
+	t.Parallel()
+
+	c := &codeCache{}
+	code := &gojq.Code{}
+
+	for i := range codeCacheSize {
+		c.add(strconv.Itoa(i), code)
+	}
+
+	// Using the oldest filter keeps it in the cache.
+	if _, ok := c.get("0"); !ok {
+		t.Fatal("want filter 0 to be cached")
+	}
+
+	c.add("new", code)
+
+	if got := c.len(); got != codeCacheSize {
+		t.Errorf("want %d filters, got %d", codeCacheSize, got)
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "0", want: true},
+		{filter: "1", want: false},
+		{filter: "2", want: true},
+		{filter: "new", want: true},
+	}
+
+	for _, test := range tests {
+		if _, ok := c.get(test.filter); ok != test.want {
+			t.Errorf("filter %s: want cached %v, got %v", test.filter, test.want, ok)
+		}
+	}
+}
+
+func TestLibraryCompileCache(t *testing.T) {
+	t.Parallel()
+
+	l := &Library{}
+
+	first, err := l.compile(".id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := l.compile(".id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Error("want the compiled filter to be reused")
+	}
+}
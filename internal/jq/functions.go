@@ -0,0 +1,215 @@
+package jq
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/itchyny/gojq"
+)
+
+// Env is the gh-not context exposed to the custom jq functions.
+//
+// The custom functions are:
+//
+//   - `now_relative("2d")`: the unix timestamp of now minus a duration, in
+//     seconds (s), minutes (m), hours (h), days (d) or weeks (w).
+//     E.g. `.updated_at | fromdate < now_relative("1w")`.
+//   - `age_hours`: the number of hours since `.updated_at`.
+//   - `has_tag("x")`: true if `.meta.tags` contains the tag.
+//   - `is_mine`: true if `.author.login` is the authenticated user.
+//   - `team_member("org/team")`: true if `.author.login` is a member of the team.
+//   - `rule_matched("name")`: true if the rule matched the notification earlier
+//     in the current run.
+type Env struct {
+	// Now returns the current time.
+	Now func() time.Time
+
+	// User returns the authenticated user's login.
+	User func() (string, error)
+
+	// TeamMembers returns the logins of a team's members, the team is in the
+	// `org/team` format.
+	TeamMembers func(team string) ([]string, error)
+
+	// RuleMatched returns true if the rule matched the notification with the
+	// given ID earlier in the current run.
+	RuleMatched func(id, rule string) bool
+}
+
+//nolint:gochecknoglobals // The environment is shared by all the filters.
+var env atomic.Pointer[Env]
+
+var (
+	errInvalidArgument = errors.New("invalid argument")
+	errNoContext       = errors.New("not available in this context")
+	durationRE         = regexp.MustCompile(`(\d+)([smhdw])`)
+)
+
+//nolint:gochecknoglobals // This map is used as a constant.
+var durationUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// SetEnv sets the environment used by the custom functions.
+func SetEnv(e *Env) {
+	env.Store(e)
+}
+
+func currentEnv() *Env {
+	if e := env.Load(); e != nil {
+		return e
+	}
+
+	return &Env{}
+}
+
+func (e *Env) now() time.Time {
+	if e.Now == nil {
+		return time.Now()
+	}
+
+	return e.Now()
+}
+
+func functions() []gojq.CompilerOption {
+	return []gojq.CompilerOption{
+		gojq.WithFunction("now_relative", 1, 1, nowRelative),
+		gojq.WithFunction("age_hours", 0, 0, ageHours),
+		gojq.WithFunction("has_tag", 1, 1, hasTag),
+		gojq.WithFunction("is_mine", 0, 0, isMine),
+		gojq.WithFunction("team_member", 1, 1, teamMember),
+		gojq.WithFunction("rule_matched", 1, 1, ruleMatched),
+	}
+}
+
+func nowRelative(_ any, args []any) any {
+	s, ok := args[0].(string)
+	if !ok {
+		return fmt.Errorf("now_relative: %w: %v", errInvalidArgument, args[0])
+	}
+
+	d, err := parseDuration(s)
+	if err != nil {
+		return fmt.Errorf("now_relative: %w", err)
+	}
+
+	return float64(currentEnv().now().Add(-d).Unix())
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	matches := durationRE.FindAllStringSubmatch(s, -1)
+
+	matched := ""
+	d := time.Duration(0)
+
+	for _, m := range matches {
+		matched += m[0]
+
+		i, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errInvalidArgument, s)
+		}
+
+		d += time.Duration(i) * durationUnits[m[2]]
+	}
+
+	if matched == "" || matched != s {
+		return 0, fmt.Errorf("%w: invalid duration %q", errInvalidArgument, s)
+	}
+
+	return d, nil
+}
+
+func ageHours(v any, _ []any) any {
+	updatedAt, ok := field(v, "updated_at").(string)
+	if !ok {
+		return fmt.Errorf("age_hours: %w: missing updated_at", errInvalidArgument)
+	}
+
+	t, err := time.Parse(time.RFC3339, updatedAt)
+	if err != nil {
+		return fmt.Errorf("age_hours: %w", err)
+	}
+
+	return currentEnv().now().Sub(t).Hours()
+}
+
+func hasTag(v any, args []any) any {
+	tags, _ := field(v, "meta", "tags").([]any)
+
+	return slices.Contains(tags, args[0])
+}
+
+func isMine(v any, _ []any) any {
+	e := currentEnv()
+	if e.User == nil {
+		return fmt.Errorf("is_mine: %w", errNoContext)
+	}
+
+	user, err := e.User()
+	if err != nil {
+		return fmt.Errorf("is_mine: %w", err)
+	}
+
+	return user != "" && field(v, "author", "login") == user
+}
+
+func teamMember(v any, args []any) any {
+	team, ok := args[0].(string)
+	if !ok {
+		return fmt.Errorf("team_member: %w: %v", errInvalidArgument, args[0])
+	}
+
+	e := currentEnv()
+	if e.TeamMembers == nil {
+		return fmt.Errorf("team_member: %w", errNoContext)
+	}
+
+	members, err := e.TeamMembers(team)
+	if err != nil {
+		return fmt.Errorf("team_member: %w", err)
+	}
+
+	login, _ := field(v, "author", "login").(string)
+
+	return login != "" && slices.Contains(members, login)
+}
+
+func ruleMatched(v any, args []any) any {
+	rule, ok := args[0].(string)
+	if !ok {
+		return fmt.Errorf("rule_matched: %w: %v", errInvalidArgument, args[0])
+	}
+
+	e := currentEnv()
+	if e.RuleMatched == nil {
+		return false
+	}
+
+	id, _ := field(v, "id").(string)
+
+	return e.RuleMatched(id, rule)
+}
+
+// field returns the nested field from a JSON-like value, or nil.
+func field(v any, path ...string) any {
+	for _, p := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		v = m[p]
+	}
+
+	return v
+}
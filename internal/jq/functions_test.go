@@ -0,0 +1,145 @@
+package jq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+var errSample = errors.New("sample")
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30s", want: 30 * time.Second},
+		{in: "2d", want: 48 * time.Hour},
+		{in: "1w", want: 7 * 24 * time.Hour},
+		{in: "1d12h", want: 36 * time.Hour},
+		{in: "", wantErr: true},
+		{in: "2", wantErr: true},
+		{in: "2y", wantErr: true},
+		{in: "2d foo", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseDuration(test.in)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Fatalf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // The environment is global.
+func TestFunctions(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	SetEnv(&Env{
+		Now: func() time.Time { return now },
+		User: func() (string, error) {
+			return "me", nil
+		},
+		TeamMembers: func(team string) ([]string, error) {
+			if team == "org/broken" {
+				return nil, errSample
+			}
+
+			return []string{"teammate"}, nil
+		},
+		RuleMatched: func(id, rule string) bool {
+			return id == "0" && rule == "first"
+		},
+	})
+	defer SetEnv(nil)
+
+	n := notifications.Notifications{
+		{ID: "0", UpdatedAt: now.Add(-72 * time.Hour), Author: notifications.User{Login: "me"}},
+		{
+			ID:        "1",
+			UpdatedAt: now.Add(-1 * time.Hour),
+			Author:    notifications.User{Login: "teammate"},
+			Meta:      notifications.Meta{Tags: []string{"x"}},
+		},
+		{ID: "2", UpdatedAt: now.Add(-24 * time.Hour), Author: notifications.User{Login: "other"}},
+	}
+
+	tests := []struct {
+		filter  string
+		want    []string
+		wantErr bool
+	}{
+		{filter: `.updated_at | fromdate < now_relative("2d")`, want: []string{"0"}},
+		{filter: `age_hours > 12`, want: []string{"0", "2"}},
+		{filter: `has_tag("x")`, want: []string{"1"}},
+		{filter: `is_mine`, want: []string{"0"}},
+		{filter: `is_mine | not`, want: []string{"1", "2"}},
+		{filter: `team_member("org/team")`, want: []string{"1"}},
+		{filter: `rule_matched("first")`, want: []string{"0"}},
+		{filter: `now_relative("nope")`, wantErr: true},
+		{filter: `team_member("org/broken")`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			got, err := Filter(test.filter, n)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !notificationsEqual(got, test.want) {
+				t.Fatalf("want %#v, got %#v", test.want, got.IDList())
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // The environment is global.
+func TestFunctionsWithoutContext(t *testing.T) {
+	SetEnv(&Env{})
+	defer SetEnv(nil)
+
+	n := notifications.Notifications{{ID: "0"}}
+
+	if _, err := Filter(`is_mine`, n); !errors.Is(err, errNoContext) {
+		t.Errorf("want %v, got %v", errNoContext, err)
+	}
+
+	if _, err := Filter(`team_member("org/team")`, n); !errors.Is(err, errNoContext) {
+		t.Errorf("want %v, got %v", errNoContext, err)
+	}
+
+	got, err := Filter(`rule_matched("first")`, n)
+	if err != nil || len(got) != 0 {
+		t.Errorf("want no match and no error, got %#v, %v", got.IDList(), err)
+	}
+}
@@ -10,68 +10,68 @@ import (
 	"github.com/nobe4/gh-not/internal/notifications"
 )
 
-var (
-	errInvalidID = errors.New("invalid ID")
-	errNextValue = errors.New("failed to get the next value")
-)
+var errNextValue = errors.New("failed to get the next value")
 
 // Filter applies a `.[] | select(filter)` on the notifications.
-// TODO: refactor this as a callback to be called on n.Filter(flt) and have
-// n.Filter call .Compact
-//
-//revive:disable:cognitive-complexity // TODO: simplify.
+// To run multiple filters on the same notifications, use a Raw instead.
 func Filter(filter string, n notifications.Notifications) (notifications.Notifications, error) {
 	if filter == "" {
 		return n, nil
 	}
 
-	// Extract the IDs from the notifications that match the filter.
-	// FilterFromIds will be used to get back the notifications from the IDs.
-	query, err := gojq.Parse(fmt.Sprintf(".[] | select(%s) | .id", filter))
+	raw, err := NewRaw(n)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse filter: %w", err)
+		return nil, err
 	}
 
-	// gojq works only on `any` data, so we need to convert Notifications to
-	// interface{}. This also gives us back the JSON fields from the API.
-	notificationsRaw, err := n.Interface()
+	return raw.Filter(filter, n)
+}
+
+// SortBy sorts the notifications in place by the filter's first output, with
+// jq's ordering, e.g. `.subject.title | ascii_downcase`.
+// A filter without output sorts as null.
+func SortBy(filter string, n notifications.Notifications, desc bool) error {
+	raw, err := NewRaw(n)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert notifications to raw interface: %w", err)
+		return err
 	}
 
-	filteredIDs := []string{}
-
-	iter := query.Run(notificationsRaw)
-
-	for {
-		v, ok := iter.Next()
-		if !ok {
-			break
-		}
+	code, err := currentLibrary().compile(filter)
+	if err != nil {
+		return err
+	}
 
-		if err, ok = v.(error); ok {
-			haltError := &gojq.HaltError{}
-			if ok = errors.As(err, &haltError); ok && haltError.Value() == nil {
-				break
-			}
+	keys := make(map[*notifications.Notification]any, len(n))
 
-			return nil, err
+	for _, notification := range n {
+		v, err := raw.value(notification)
+		if err != nil {
+			return err
 		}
 
-		newID, ok := v.(string)
-		if !ok {
-			return nil, fmt.Errorf("invalid filtered id %#v: %w", v, errInvalidID)
+		key, _ := code.Run(v).Next()
+		if err, ok := key.(error); ok {
+			return fmt.Errorf("failed to run filter on %s: %w", notification.ID, err)
 		}
 
-		filteredIDs = append(filteredIDs, newID)
+		keys[notification] = key
 	}
 
-	return n.FilterFromIDs(filteredIDs), nil
+	n.SortBy(func(a, b *notifications.Notification) int {
+		return gojq.Compare(keys[a], keys[b])
+	}, desc)
+
+	return nil
 }
 
+// Validate checks that the filter can be compiled, with the shared library.
 func Validate(filter string) error {
-	if _, err := gojq.Parse(filter); err != nil {
-		return fmt.Errorf("failed to parse filter: %w", err)
+	if filter == "" {
+		filter = "."
+	}
+
+	if _, err := currentLibrary().compile(filter); err != nil {
+		return err
 	}
 
 	return nil
@@ -91,9 +91,9 @@ func Run(filter string, n notifications.Notification) (string, error) {
 		return "", fmt.Errorf("failed to convert notifications to raw interface: %w", err)
 	}
 
-	query, err := gojq.Parse(filter)
+	query, err := currentLibrary().compile(filter)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse filter: %w", err)
+		return "", err
 	}
 
 	v, ok := query.Run(notificationsRaw).Next()
@@ -162,3 +162,42 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter string
+		desc   bool
+		want   []string
+	}{
+		{filter: `.subject.title | ascii_downcase`, want: []string{"1", "2", "0"}},
+		{filter: `.subject.title | ascii_downcase`, desc: true, want: []string{"0", "2", "1"}},
+		{filter: `.subject.title | length`, want: []string{"1", "0", "2"}},
+		{filter: `empty`, want: []string{"0", "1", "2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			t.Parallel()
+
+			n := notifications.Notifications{
+				&notifications.Notification{ID: "0", Subject: notifications.Subject{Title: "c.."}},
+				&notifications.Notification{ID: "1", Subject: notifications.Subject{Title: "A"}},
+				&notifications.Notification{ID: "2", Subject: notifications.Subject{Title: "b...."}},
+			}
+
+			if err := SortBy(test.filter, n, test.desc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !notificationsEqual(n, test.want) {
+				t.Errorf("want %v, got %v", test.want, n.IDList())
+			}
+		})
+	}
+
+	if err := SortBy(`.[`, notifications.Notifications{}, false); err == nil {
+		t.Error("want an error, got nil")
+	}
+}
@@ -0,0 +1,123 @@
+package jq
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/itchyny/gojq"
+)
+
+// Library holds the jq definitions and modules shared by all the filters.
+//
+// Definitions are prepended to each filter, and every `.jq` module found in
+// the paths is included before them, so that their functions can be used
+// directly.
+//
+// E.g. with a module `org.jq` containing `def ours: .repository.owner.login == "org";`
+// and the definitions `def mine: .author.login == "me";`, the filter
+// `ours and mine` becomes:
+//
+//	include "org"; def mine: .author.login == "me"; ours and mine
+//
+// The most recently compiled filters are cached, so that compiling the same
+// filter again is free.
+type Library struct {
+	definitions string
+	paths       []string
+	modules     []string
+
+	// codes holds the recently compiled filters.
+	codes codeCache
+}
+
+//nolint:gochecknoglobals // The library is shared by all the filters.
+var (
+	library        atomic.Pointer[Library]
+	defaultLibrary = &Library{}
+)
+
+// NewLibrary creates a Library from the definitions and the modules found in
+// the paths.
+func NewLibrary(definitions string, paths []string) (*Library, error) {
+	l := &Library{
+		definitions: strings.TrimSpace(definitions),
+		paths:       paths,
+	}
+
+	for _, path := range paths {
+		matches, err := filepath.Glob(filepath.Join(path, "*.jq"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list modules in %s: %w", path, err)
+		}
+
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || info.IsDir() {
+				continue
+			}
+
+			l.modules = append(l.modules, strings.TrimSuffix(filepath.Base(match), ".jq"))
+		}
+	}
+
+	slices.Sort(l.modules)
+	l.modules = slices.Compact(l.modules)
+
+	if _, err := l.compile("."); err != nil {
+		return nil, fmt.Errorf("invalid jq library: %w", err)
+	}
+
+	return l, nil
+}
+
+// SetLibrary sets the library used by Filter, Run and Validate.
+func SetLibrary(l *Library) {
+	library.Store(l)
+}
+
+func currentLibrary() *Library {
+	if l := library.Load(); l != nil {
+		return l
+	}
+
+	return defaultLibrary
+}
+
+func (l *Library) prelude() string {
+	var b strings.Builder
+
+	for _, module := range l.modules {
+		fmt.Fprintf(&b, "include %q; ", module)
+	}
+
+	if l.definitions != "" {
+		b.WriteString(l.definitions + " ")
+	}
+
+	return b.String()
+}
+
+func (l *Library) compile(filter string) (*gojq.Code, error) {
+	if code, ok := l.codes.get(filter); ok {
+		return code, nil
+	}
+
+	query, err := gojq.Parse(l.prelude() + filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filter: %w", err)
+	}
+
+	options := append(functions(), gojq.WithModuleLoader(gojq.NewModuleLoader(l.paths)))
+
+	code, err := gojq.Compile(query, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile filter: %w", err)
+	}
+
+	l.codes.add(filter, code)
+
+	return code, nil
+}
@@ -0,0 +1,97 @@
+package jq
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLibrary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	module := `def ours: .repository.owner.login == "org";`
+	if err := os.WriteFile(filepath.Join(dir, "org.jq"), []byte(module), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLibrary(`def mine: .author.login == "me";`, []string{dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `include "org"; def mine: .author.login == "me"; `
+	if got := l.prelude(); got != want {
+		t.Fatalf("want prelude %q, got %q", want, got)
+	}
+
+	code, err := l.compile("ours and mine")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		input map[string]any
+		want  bool
+	}{
+		{
+			input: map[string]any{
+				"repository": map[string]any{"owner": map[string]any{"login": "org"}},
+				"author":     map[string]any{"login": "me"},
+			},
+			want: true,
+		},
+		{
+			input: map[string]any{
+				"repository": map[string]any{"owner": map[string]any{"login": "other"}},
+				"author":     map[string]any{"login": "me"},
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		v, ok := code.Run(test.input).Next()
+		if !ok {
+			t.Fatal("expected a value")
+		}
+
+		if v != test.want {
+			t.Errorf("want %v, got %v", test.want, v)
+		}
+	}
+}
+
+func TestLibraryErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid definitions", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewLibrary(`def broken: ;`, nil); err == nil {
+			t.Fatal("expected an error but got none")
+		}
+	})
+
+	t.Run("invalid module", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "bad.jq"), []byte(`def x: ;`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := NewLibrary("", []string{dir}); err == nil {
+			t.Fatal("expected an error but got none")
+		}
+	})
+
+	t.Run("undefined function", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := (&Library{}).compile("undefined_function"); err == nil {
+			t.Fatal("expected an error but got none")
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package jq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+// Raw holds notifications converted to the format gojq works on.
+//
+// gojq works only on `any` data, so the notifications need to be marshaled to
+// JSON and back, which is slow. Raw does it once so that multiple filters can
+// run on the same notifications.
+// If a notification changes, Update needs to be called for the filters to see
+// the changes.
+type Raw struct {
+	values map[*notifications.Notification]any
+}
+
+// NewRaw converts the notifications.
+func NewRaw(n notifications.Notifications) (*Raw, error) {
+	n = slices.Clone(n).Compact()
+
+	// This also gives us back the JSON fields from the API.
+	list, err := n.Interface()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert notifications to raw interface: %w", err)
+	}
+
+	values, _ := list.([]any)
+
+	r := &Raw{values: make(map[*notifications.Notification]any, len(n))}
+
+	for i, v := range values {
+		r.values[n[i]] = v
+	}
+
+	return r, nil
+}
+
+// Update converts the notification again.
+func (r *Raw) Update(n *notifications.Notification) error {
+	v, err := n.Interface()
+	if err != nil {
+		return fmt.Errorf("failed to convert notification to raw interface: %w", err)
+	}
+
+	r.values[n] = v
+
+	return nil
+}
+
+func (r *Raw) value(n *notifications.Notification) (any, error) {
+	if v, ok := r.values[n]; ok {
+		return v, nil
+	}
+
+	if err := r.Update(n); err != nil {
+		return nil, err
+	}
+
+	return r.values[n], nil
+}
+
+// Filter applies a `.[] | select(filter)` on the notifications.
+// The notifications that aren't already converted are converted on the fly.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func (r *Raw) Filter(filter string, n notifications.Notifications) (notifications.Notifications, error) {
+	if filter == "" {
+		return n, nil
+	}
+
+	code, err := currentLibrary().compile(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := notifications.Notifications{}
+
+	for _, notification := range n {
+		if notification == nil {
+			continue
+		}
+
+		v, err := r.value(notification)
+		if err != nil {
+			return nil, err
+		}
+
+		matched, halted, err := match(code, v)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			filtered = append(filtered, notification)
+		}
+
+		if halted {
+			break
+		}
+	}
+
+	return filtered, nil
+}
+
+// match reports whether any of the filter's outputs is truthy, like
+// `select(filter)` would, and whether the filter was halted, in which case
+// the remaining notifications must not be evaluated.
+func match(code *gojq.Code, v any) (bool, bool, error) {
+	iter := code.Run(v)
+
+	for {
+		out, ok := iter.Next()
+		if !ok {
+			return false, false, nil
+		}
+
+		if err, ok := out.(error); ok {
+			haltError := &gojq.HaltError{}
+			if errors.As(err, &haltError) && haltError.Value() == nil {
+				return false, true, nil
+			}
+
+			return false, false, err
+		}
+
+		if out != nil && out != false {
+			return true, false, nil
+		}
+	}
+}
+
+// String runs the filter on a notification and returns its outputs, joined
+// with `, `. Strings are returned as is, null is omitted and the other values
+// are returned as JSON.
+func (r *Raw) String(filter string, n *notifications.Notification) (string, error) {
+	code, err := currentLibrary().compile(filter)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := r.value(n)
+	if err != nil {
+		return "", err
+	}
+
+	outputs := []string{}
+	iter := code.Run(v)
+
+	for {
+		out, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if err, ok := out.(error); ok {
+			return "", fmt.Errorf("failed to run filter: %w", err)
+		}
+
+		switch out := out.(type) {
+		case nil:
+		case string:
+			outputs = append(outputs, out)
+		default:
+			marshaled, err := json.Marshal(out)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal result: %w", err)
+			}
+
+			outputs = append(outputs, string(marshaled))
+		}
+	}
+
+	return strings.Join(outputs, ", "), nil
+}
@@ -0,0 +1,229 @@
+package jq
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+func TestRaw(t *testing.T) {
+	t.Parallel()
+
+	n0 := &notifications.Notification{ID: "0"}
+	n1 := &notifications.Notification{ID: "1"}
+	n := notifications.Notifications{n0, nil, n1}
+
+	raw, err := NewRaw(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := raw.Filter(`.id == "1"`, n)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !notificationsEqual(got, []string{"1"}) {
+			t.Fatalf("want [1], got %#v", got.IDList())
+		}
+	})
+
+	t.Run("converts unknown notifications", func(t *testing.T) {
+		t.Parallel()
+
+		n2 := &notifications.Notification{ID: "2"}
+
+		got, err := raw.Filter(`.id == "2"`, notifications.Notifications{n0, n2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !notificationsEqual(got, []string{"2"}) {
+			t.Fatalf("want [2], got %#v", got.IDList())
+		}
+	})
+
+	t.Run("halts", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := raw.Filter(`if .id == "1" then halt else true end`, n)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !notificationsEqual(got, []string{"0"}) {
+			t.Fatalf("want [0], got %#v", got.IDList())
+		}
+	})
+}
+
+func TestRawUpdate(t *testing.T) {
+	t.Parallel()
+
+	n0 := &notifications.Notification{ID: "0"}
+	n := notifications.Notifications{n0}
+
+	raw, err := NewRaw(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	n0.Unread = true
+
+	got, err := raw.Filter(`.unread`, n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Fatalf("want the stale value to not match, got %#v", got.IDList())
+	}
+
+	if err := raw.Update(n0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err = raw.Filter(`.unread`, n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !notificationsEqual(got, []string{"0"}) {
+		t.Fatalf("want [0], got %#v", got.IDList())
+	}
+}
+
+func TestRawString(t *testing.T) {
+	t.Parallel()
+
+	n := &notifications.Notification{
+		ID:     "0",
+		Reason: "mention",
+		Meta:   notifications.Meta{Tags: []string{"a", "b"}},
+	}
+
+	raw, err := NewRaw(notifications.Notifications{n})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{filter: `.reason`, want: "mention"},
+		{filter: `.meta.tags[]`, want: "a, b"},
+		{filter: `.meta.tags`, want: `["a","b"]`},
+		{filter: `.meta.tags | length`, want: "2"},
+		{filter: `.nope`, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := raw.String(test.filter, n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func benchmarkNotifications(count int) notifications.Notifications {
+	n := make(notifications.Notifications, 0, count)
+
+	for i := range count {
+		n = append(n, &notifications.Notification{
+			ID:     strconv.Itoa(i),
+			Reason: "subscribed",
+			Repository: notifications.Repository{
+				FullName: fmt.Sprintf("org/repo%d", i%20),
+			},
+			Subject: notifications.Subject{Title: fmt.Sprintf("title %d", i)},
+		})
+	}
+
+	return n
+}
+
+func benchmarkFilters(count int) []string {
+	filters := make([]string, 0, count)
+
+	for i := range count {
+		filters = append(filters, fmt.Sprintf(`.repository.full_name == "org/repo%d"`, i))
+	}
+
+	return filters
+}
+
+// BenchmarkFilterUncached is how the filters used to run: parsed and compiled,
+// with all the notifications converted, for each filter.
+func BenchmarkFilterUncached(b *testing.B) {
+	n := benchmarkNotifications(2000)
+	filters := benchmarkFilters(60)
+
+	for b.Loop() {
+		for _, filter := range filters {
+			raw, err := NewRaw(n)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			code, err := (&Library{}).compile(filter)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for _, notification := range n {
+				if _, _, err := match(code, raw.values[notification]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkFilter runs the filters with the cached compilation, but converts
+// the notifications for each filter.
+func BenchmarkFilter(b *testing.B) {
+	n := benchmarkNotifications(2000)
+	filters := benchmarkFilters(60)
+
+	for b.Loop() {
+		for _, filter := range filters {
+			if _, err := Filter(filter, n); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkRawFilter runs the filters with the cached compilation, and
+// converts the notifications once, like Manager.Apply does.
+func BenchmarkRawFilter(b *testing.B) {
+	n := benchmarkNotifications(2000)
+	filters := benchmarkFilters(60)
+
+	for b.Loop() {
+		raw, err := NewRaw(n)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, filter := range filters {
+			if _, err := raw.Filter(filter, n); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/nobe4/gh-not/internal/jq"
+)
+
+// setEnv exposes the manager's context to the custom jq functions.
+// They use the manager's clock, like the rules' schedules.
+func (m *Manager) setEnv() {
+	jq.SetEnv(&jq.Env{
+		Now:         m.Now,
+		User:        m.user,
+		TeamMembers: m.teamMembers,
+		RuleMatched: m.ruleMatched,
+	})
+}
+
+func (m *Manager) user() (string, error) {
+	if m.client == nil {
+		return "", fmt.Errorf("cannot get the user: %w", errNoClient)
+	}
+
+	//nolint:wrapcheck // The error is already wrapped by the client.
+	return m.client.User()
+}
+
+func (m *Manager) teamMembers(team string) ([]string, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("cannot get the team members: %w", errNoClient)
+	}
+
+	//nolint:wrapcheck // The error is already wrapped by the client.
+	return m.client.TeamMembers(team)
+}
+
+func (m *Manager) ruleMatched(id, rule string) bool {
+	return slices.Contains(m.matched[id], rule)
+}
+
+func (m *Manager) recordMatch(id, rule string) {
+	if m.matched == nil {
+		m.matched = map[string][]string{}
+	}
+
+	m.matched[id] = append(m.matched[id], rule)
+}
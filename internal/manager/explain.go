@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+// Trace describes how a rule applies to a single notification.
+type Trace struct {
+	Rule config.Rule
+
+	// Index is the position of the rule in the config, before sorting.
+	Index int
+
+	Filters []config.FilterResult
+	Matched bool
+
+	// Skipped is the reason why the rule's actions wouldn't run, if any.
+	Skipped string
+}
+
+// Explain evaluates the rules on a single notification, in the same order and
+// with the same logic as Apply, without running any action.
+func (m *Manager) Explain(n *notifications.Notification) []Trace {
+	traces := []Trace{}
+	stoppedBy := ""
+	now := m.Now()
+	m.matched = map[string][]string{}
+
+	for _, i := range config.RuleOrder(m.config.Rules) {
+		rule := m.config.Rules[i]
+		t := Trace{Rule: rule, Index: i}
+		t.Filters, t.Matched = rule.Explain(n)
+
+		scheduled := rule.Scheduled(now)
+		applied := t.Matched && rule.IsEnabled() && scheduled && stoppedBy == ""
+
+		switch {
+		case !rule.IsEnabled():
+			t.Skipped = "rule is disabled"
+
+		case !scheduled:
+			t.Skipped = "outside of the rule's schedule"
+
+		case stoppedBy != "":
+			t.Skipped = fmt.Sprintf("stopped by rule %q", stoppedBy)
+
+		case t.Matched && n.Meta.Done && !m.ForceStrategy.Has(ForceApply):
+			t.Skipped = "notification is done"
+
+		default:
+		}
+
+		if applied {
+			m.recordMatch(n.ID, rule.Name)
+
+			if !rule.Continues() {
+				stoppedBy = rule.Name
+			}
+		}
+
+		traces = append(traces, t)
+	}
+
+	return traces
+}
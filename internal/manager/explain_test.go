@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	no := false
+
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Name: "disabled", Filters: []string{`true`}, Action: "pass", Enabled: &no},
+			{Name: "no match", Filters: []string{`false`}, Action: "pass"},
+			{Name: "stop", Filters: []string{`true`}, Action: "pass", Continue: &no},
+			{Name: "stopped", Filters: []string{`true`}, Action: "pass"},
+			{Name: "first", Filters: []string{`true`}, Action: "pass", Priority: 1},
+			{Name: "weekend", Filters: []string{`true`}, Action: "pass", Schedule: &config.Schedule{Days: []string{"sat"}}},
+		}},
+		now: func() time.Time {
+			return time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+		},
+	}
+
+	traces := m.Explain(&notifications.Notification{ID: "0"})
+
+	want := []struct {
+		name    string
+		index   int
+		matched bool
+		skipped string
+	}{
+		{"first", 4, true, ""},
+		{"disabled", 0, true, "rule is disabled"},
+		{"no match", 1, false, ""},
+		{"stop", 2, true, ""},
+		{"stopped", 3, true, `stopped by rule "stop"`},
+		{"weekend", 5, true, "outside of the rule's schedule"},
+	}
+
+	if len(traces) != len(want) {
+		t.Fatalf("want %d traces, got %d", len(want), len(traces))
+	}
+
+	for i, w := range want {
+		got := traces[i]
+
+		if got.Rule.Name != w.name || got.Index != w.index || got.Matched != w.matched || got.Skipped != w.skipped {
+			t.Errorf("trace %d: want %+v, got name=%q index=%d matched=%v skipped=%q",
+				i, w, got.Rule.Name, got.Index, got.Matched, got.Skipped)
+		}
+	}
+}
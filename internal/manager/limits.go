@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nobe4/gh-not/internal/config"
+)
+
+var errLimitExceeded = errors.New("limit exceeded")
+
+// limits keeps track of the actions run by the rules, to enforce the rules'
+// `max_per_run` and the global `apply.max_actions`.
+type limits struct {
+	maxActions int
+	total      int
+	applied    []string
+}
+
+// check returns an error if applying the rule on the notifications would go
+// over a limit.
+func (l *limits) check(rule config.Rule, notifications, actions int) error {
+	if rule.MaxPerRun > 0 && notifications > rule.MaxPerRun {
+		return l.exceeded(fmt.Sprintf("rule %q would act on %d notifications, max_per_run is %d",
+			rule.Name, notifications, rule.MaxPerRun))
+	}
+
+	if l.maxActions > 0 && l.total+actions > l.maxActions {
+		return l.exceeded(fmt.Sprintf("rule %q would run %d actions, %d of apply.max_actions %d are left",
+			rule.Name, actions, l.maxActions-l.total, l.maxActions))
+	}
+
+	return nil
+}
+
+// add records the actions run by the rule.
+func (l *limits) add(rule config.Rule, notifications, actions int) {
+	if notifications == 0 {
+		return
+	}
+
+	l.total += actions
+	l.applied = append(l.applied, fmt.Sprintf("rule %q: %d notifications, %d actions",
+		rule.Name, notifications, actions))
+}
+
+func (l *limits) exceeded(reason string) error {
+	summary := "no action was run"
+	if len(l.applied) > 0 {
+		summary = "already run:\n  - " + strings.Join(l.applied, "\n  - ")
+	}
+
+	return fmt.Errorf("%w: %s, aborting\n%s", errLimitExceeded, reason, summary)
+}
@@ -3,6 +3,7 @@ package manager
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/nobe4/gh-not/internal/cache"
 	"github.com/nobe4/gh-not/internal/config"
 	"github.com/nobe4/gh-not/internal/gh"
+	"github.com/nobe4/gh-not/internal/jq"
 	"github.com/nobe4/gh-not/internal/notifications"
 )
 
@@ -26,6 +28,18 @@ type Manager struct {
 
 	RefreshStrategy RefreshStrategy
 	ForceStrategy   ForceStrategy
+
+	// now returns the current time, used for the rules' schedules.
+	// Defaults to time.Now.
+	now func() time.Time
+
+	// out receives the actions' and the refresh's messages.
+	// Defaults to os.Stdout.
+	out io.Writer
+
+	// matched holds the names of the rules that matched each notification,
+	// by ID, during the current run.
+	matched map[string][]string
 }
 
 var errNoClient = errors.New("no client set")
@@ -35,6 +49,7 @@ func New(c *config.Data) *Manager {
 
 	m.config = c
 	m.Cache = cache.NewFileCache(m.config.Cache.Path)
+	m.setEnv()
 
 	return m
 }
@@ -44,6 +59,24 @@ func (m *Manager) SetCaller(caller api.Requestor) {
 	m.Actions = actions.GetMap(m.client)
 }
 
+// SetOutput sets where the actions' and the refresh's messages are written,
+// e.g. away from a running REPL.
+func (m *Manager) SetOutput(w io.Writer) {
+	m.out = w
+}
+
+// Copy returns a copy of the manager with a deep copy of the notifications, to
+// refresh them in the background while they're in use, e.g. in the REPL.
+// The jq functions then use the copy's context, e.g. `rule_matched`.
+func (m *Manager) Copy() *Manager {
+	c := *m
+	c.Notifications = m.Notifications.Clone()
+	c.matched = nil
+	c.setEnv()
+
+	return &c
+}
+
 func (m *Manager) Load() error {
 	if err := m.Cache.Read(&m.Notifications); err != nil {
 		slog.Warn("cannot read the cache", "error", err)
@@ -55,9 +88,15 @@ func (m *Manager) Load() error {
 }
 
 func (m *Manager) Refresh() error {
+	return m.RefreshWith(m.RefreshStrategy)
+}
+
+// RefreshWith is like Refresh, with the given strategy instead of the
+// manager's.
+func (m *Manager) RefreshWith(strategy RefreshStrategy) error {
 	expired := time.Now().After(m.Cache.RefreshedAt().Add(time.Duration(m.config.Cache.TTLInHours) * time.Hour))
 
-	if m.RefreshStrategy.ShouldRefresh(expired) {
+	if strategy.ShouldRefresh(expired) {
 		return m.refreshNotifications()
 	}
 
@@ -66,50 +105,107 @@ func (m *Manager) Refresh() error {
 	return nil
 }
 
+// Save writes the notifications to the cache, keeping the latest
+// `cache.history_size` events of their history.
 func (m *Manager) Save() error {
-	if err := m.Cache.Write(m.Notifications.Compact()); err != nil {
+	m.Notifications = m.Notifications.Compact()
+	m.Notifications.TrimHistory(m.config.Cache.HistorySize)
+
+	if err := m.Cache.Write(m.Notifications); err != nil {
 		return fmt.Errorf("cannot save the cache: %w", err)
 	}
 
 	return nil
 }
 
+// Apply runs the enabled rules, by descending priority, on the notifications.
+// A notification matched by a rule that doesn't continue is skipped by the
+// following rules.
+// Apply stops before running a rule that would exceed the rule's
+// `max_per_run` or the global `apply.max_actions`, the actions already run are
+// kept.
+//
 //revive:disable:cognitive-complexity // TODO: simplify.
 func (m *Manager) Apply() error {
-	for _, rule := range m.config.Rules {
-		runner, ok := m.Actions[rule.Action]
+	stopped := map[string]string{}
+	m.matched = map[string][]string{}
+	budget := &limits{maxActions: m.config.Apply.MaxActions}
 
-		if !ok {
-			return fmt.Errorf("%w: %v", errInvalidAction, rule.Action)
+	// Converting the notifications once for all the rules is much faster than
+	// doing it for each filter.
+	raw, err := jq.NewRaw(m.Notifications)
+	if err != nil {
+		return fmt.Errorf("failed to convert notifications: %w", err)
+	}
+
+	for _, rule := range config.SortRules(m.config.Rules) {
+		if !rule.IsEnabled() {
+			slog.Debug("skipping disabled rule", "name", rule.Name)
+
+			continue
 		}
 
-		selectedNotifications, err := rule.Filter(m.Notifications)
+		if !rule.Scheduled(m.Now()) {
+			slog.Debug("skipping rule outside of its schedule", "name", rule.Name)
+
+			continue
+		}
+
+		runs, err := m.runs(rule)
+		if err != nil {
+			return err
+		}
+
+		selectedNotifications, err := rule.FilterRaw(raw, m.Notifications)
 		if err != nil {
 			return fmt.Errorf("failed to filter notifications: %w", err)
 		}
 
 		slog.Debug("apply rule", "name", rule.Name, "count", len(selectedNotifications))
 
+		targets := notifications.Notifications{}
+
 		for _, notification := range selectedNotifications {
-			if notification.Meta.Done && !m.ForceStrategy.Has(ForceApply) {
-				slog.Debug("skipping done notification", "id", notification.ID)
+			if by, ok := stopped[notification.ID]; ok {
+				slog.Debug("skipping stopped notification", "id", notification.ID, "stopped by", by)
 
 				continue
 			}
 
-			if m.ForceStrategy.Has(ForceNoop) {
-				//nolint:forbidigo // This is an expected print statement.
-				fmt.Printf("NOOP'ing action %s on notification %s\n", rule.Action, notification.String())
+			m.recordMatch(notification.ID, rule.Name)
+
+			if !rule.Continues() {
+				stopped[notification.ID] = rule.Name
+			}
+
+			if notification.Meta.Done && !m.ForceStrategy.Has(ForceApply) {
+				slog.Debug("skipping done notification", "id", notification.ID)
 
 				continue
 			}
 
-			if err := runner.Run(notification, rule.Args, os.Stdout); err != nil {
-				slog.Error("action failed", "action", rule.Action, "err", err)
+			targets = append(targets, notification)
+		}
+
+		if err := budget.check(rule, len(targets), len(targets)*len(runs)); err != nil {
+			m.Notifications = m.Notifications.Compact()
+
+			return err
+		}
+
+		for _, notification := range targets {
+			for _, run := range runs {
+				m.run(run, notification, rule.Name)
 			}
 
-			fmt.Fprintln(os.Stdout, "")
+			// The actions may have changed the notification, the next rules need
+			// to see it.
+			if err := raw.Update(notification); err != nil {
+				return fmt.Errorf("failed to convert notification: %w", err)
+			}
 		}
+
+		budget.add(rule, len(targets), len(targets)*len(runs))
 	}
 
 	m.Notifications = m.Notifications.Compact()
@@ -117,20 +213,78 @@ func (m *Manager) Apply() error {
 	return nil
 }
 
+// Now returns the current time, e.g. to record the history of the
+// notifications changed outside of the manager.
+func (m *Manager) Now() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+
+	return m.now()
+}
+
+func (m *Manager) output() io.Writer {
+	if m.out == nil {
+		return os.Stdout
+	}
+
+	return m.out
+}
+
+type run struct {
+	name   string
+	runner actions.Runner
+	args   []string
+}
+
+func (m *Manager) runs(rule config.Rule) ([]run, error) {
+	ruleActions := rule.ActionList()
+	runs := make([]run, 0, len(ruleActions))
+
+	for _, action := range ruleActions {
+		runner, ok := m.Actions[action.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", errInvalidAction, action.Name)
+		}
+
+		runs = append(runs, run{name: action.Name, runner: runner, args: action.Args})
+	}
+
+	return runs, nil
+}
+
+func (m *Manager) run(r run, notification *notifications.Notification, rule string) {
+	if m.ForceStrategy.Has(ForceNoop) {
+		fmt.Fprintf(m.output(), "NOOP'ing action %s on notification %s\n", r.name, notification.String())
+
+		return
+	}
+
+	before := notification.Status()
+
+	err := r.runner.Run(notification, r.args, m.output())
+	if err != nil {
+		slog.Error("action failed", "action", r.name, "err", err)
+	}
+
+	notification.RecordAction(before, m.Now(), r.name, r.args, rule, err)
+
+	fmt.Fprintln(m.output(), "")
+}
+
 func (m *Manager) refreshNotifications() error {
 	if m.client == nil {
 		return fmt.Errorf("cannot refresh notifications: %w", errNoClient)
 	}
 
-	//nolint:forbidigo // This is an expected print statement.
-	fmt.Print("Refreshing notifications...\n")
+	fmt.Fprint(m.output(), "Refreshing notifications...\n")
 
 	remoteNotifications, err := m.client.Notifications()
 	if err != nil {
 		return fmt.Errorf("error listing remote notifications: %w", err)
 	}
 
-	m.Notifications = notifications.Sync(m.Notifications, remoteNotifications)
+	m.Notifications = notifications.Sync(m.Notifications, remoteNotifications, m.Now())
 	m.Notifications = m.Notifications.Uniq()
 	m.Enrich(m.Notifications)
 
@@ -0,0 +1,435 @@
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nobe4/gh-not/internal/actions"
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+
+	tests := []struct {
+		name  string
+		rules []config.Rule
+		want  map[string][]string
+	}{
+		{
+			name: "runs all the actions in order",
+			rules: []config.Rule{
+				{
+					Filters: []string{`.id == "0"`},
+					Action:  "tag",
+					Args:    []string{"a"},
+					Actions: []config.RuleAction{
+						{Name: "tag", Args: []string{"b"}},
+						{Name: "tag", Args: []string{"-a"}},
+					},
+				},
+			},
+			want: map[string][]string{"0": {"b"}, "1": nil},
+		},
+		{
+			name: "skips disabled rules",
+			rules: []config.Rule{
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"a"}, Enabled: &no},
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"b"}, Enabled: &yes},
+			},
+			want: map[string][]string{"0": {"b"}, "1": {"b"}},
+		},
+		{
+			name: "stops processing matched notifications",
+			rules: []config.Rule{
+				{Filters: []string{`.id == "0"`}, Action: "tag", Args: []string{"a"}, Continue: &no},
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"b"}},
+			},
+			want: map[string][]string{"0": {"a"}, "1": {"b"}},
+		},
+		{
+			name: "runs by priority",
+			rules: []config.Rule{
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"a"}},
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"b"}, Priority: 1, Continue: &no},
+			},
+			want: map[string][]string{"0": {"b"}, "1": {"b"}},
+		},
+		{
+			name: "later rules see the previous actions",
+			rules: []config.Rule{
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"a"}},
+				{Filters: []string{`.meta.tags | index("a")`}, Action: "tag", Args: []string{"b"}},
+			},
+			want: map[string][]string{"0": {"a", "b"}, "1": {"a", "b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Manager{
+				config:  &config.Data{Rules: test.rules},
+				Actions: actions.GetMap(nil),
+				Notifications: notifications.Notifications{
+					{ID: "0"},
+					{ID: "1"},
+				},
+			}
+
+			if err := m.Apply(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, n := range m.Notifications {
+				if !slices.Equal(n.Meta.Tags, test.want[n.ID]) {
+					t.Errorf("notification %s: want tags %#v, got %#v", n.ID, test.want[n.ID], n.Meta.Tags)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyInvalidAction(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Filters: []string{`true`}, Actions: []config.RuleAction{{Name: "nope"}}},
+		}},
+		Actions: actions.GetMap(nil),
+	}
+
+	if err := m.Apply(); err == nil {
+		t.Fatal("expected an error but got none")
+	}
+}
+
+//nolint:paralleltest // The jq environment is global.
+func TestApplyRuleMatched(t *testing.T) {
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Name: "first", Filters: []string{`.id == "0"`}, Action: "pass"},
+			{Filters: []string{`rule_matched("first")`}, Action: "tag", Args: []string{"a"}},
+		}},
+		Actions: actions.GetMap(nil),
+		Notifications: notifications.Notifications{
+			{ID: "0"},
+			{ID: "1"},
+		},
+	}
+	m.setEnv()
+
+	if err := m.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(m.Notifications[0].Meta.Tags, []string{"a"}) {
+		t.Errorf("want tags [a], got %#v", m.Notifications[0].Meta.Tags)
+	}
+
+	if len(m.Notifications[1].Meta.Tags) != 0 {
+		t.Errorf("want no tags, got %#v", m.Notifications[1].Meta.Tags)
+	}
+}
+
+//nolint:paralleltest // The jq environment is global.
+func TestApplyClock(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Filters: []string{`age_hours > 24`}, Action: "tag", Args: []string{"old"}},
+		}},
+		Actions: actions.GetMap(nil),
+		Notifications: notifications.Notifications{
+			{ID: "0", UpdatedAt: now.Add(-48 * time.Hour)},
+			{ID: "1", UpdatedAt: now.Add(-time.Hour)},
+		},
+	}
+	m.setEnv()
+
+	// The functions read the clock when the filters run.
+	m.now = func() time.Time { return now }
+
+	if err := m.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(m.Notifications[0].Meta.Tags, []string{"old"}) {
+		t.Errorf("want tags [old], got %#v", m.Notifications[0].Meta.Tags)
+	}
+
+	if len(m.Notifications[1].Meta.Tags) != 0 {
+		t.Errorf("want no tags, got %#v", m.Notifications[1].Meta.Tags)
+	}
+}
+
+//nolint:paralleltest // The jq environment is global.
+func TestCopyRuleMatched(t *testing.T) {
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Name: "first", Filters: []string{`.id == "0"`}, Action: "pass"},
+			{Filters: []string{`rule_matched("first")`}, Action: "tag", Args: []string{"a"}},
+		}},
+		Actions:       actions.GetMap(nil),
+		Notifications: notifications.Notifications{{ID: "0"}, {ID: "1"}},
+	}
+	m.setEnv()
+
+	c := m.Copy()
+
+	if err := c.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(c.Notifications[0].Meta.Tags, []string{"a"}) {
+		t.Errorf("want tags [a], got %#v", c.Notifications[0].Meta.Tags)
+	}
+
+	if len(c.Notifications[1].Meta.Tags) != 0 {
+		t.Errorf("want no tags, got %#v", c.Notifications[1].Meta.Tags)
+	}
+}
+
+func TestApplyHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1, 0)
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Name: "hide", Filters: []string{`.id == "0"`}, Action: "hide"},
+		}},
+		Actions:       actions.GetMap(nil),
+		Notifications: notifications.Notifications{{ID: "0"}, {ID: "1"}},
+		now:           func() time.Time { return now },
+	}
+
+	if err := m.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []notifications.Event{
+		{Time: now, Type: notifications.EventAction, Action: "hide", Rule: "hide"},
+		{Time: now, Type: notifications.EventHidden},
+	}
+
+	if got := m.Notifications[0].Meta.History; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	if got := m.Notifications[1].Meta.History; len(got) != 0 {
+		t.Errorf("want no history, got %#v", got)
+	}
+}
+
+func TestApplyOutput(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Filters: []string{`.id == "0"`}, Action: "tag", Args: []string{"a"}},
+		}},
+		Actions:       actions.GetMap(nil),
+		Notifications: notifications.Notifications{{ID: "0"}},
+	}
+	m.SetOutput(out)
+
+	if err := m.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "TAGGED") {
+		t.Errorf("want the action's output, got %q", out.String())
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{Filters: []string{`true`}, Action: "hide"},
+		}},
+		Actions:       actions.GetMap(nil),
+		Notifications: notifications.Notifications{{ID: "0"}, {ID: "1"}},
+		out:           &bytes.Buffer{},
+	}
+
+	c := m.Copy()
+	done := make(chan error)
+
+	// Applying the rules on the copy runs alongside reading the original, as
+	// when the REPL refreshes.
+	go func() { done <- c.Apply() }()
+
+	for _, n := range m.Notifications {
+		_ = n.Meta.Hidden
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range m.Notifications {
+		if m.Notifications[i].Meta.Hidden || len(m.Notifications[i].Meta.History) != 0 {
+			t.Errorf("want the original unchanged, got %+v", m.Notifications[i])
+		}
+
+		if !c.Notifications[i].Meta.Hidden {
+			t.Errorf("want the copy hidden, got %+v", c.Notifications[i])
+		}
+	}
+}
+
+func BenchmarkApply(b *testing.B) {
+	rules := make([]config.Rule, 0, 60)
+	for i := range 60 {
+		rules = append(rules, config.Rule{
+			Filters: []string{fmt.Sprintf(`.repository.full_name == "org/repo%d"`, i), `.reason == "none"`},
+			Action:  "pass",
+		})
+	}
+
+	n := make(notifications.Notifications, 0, 2000)
+	for i := range 2000 {
+		n = append(n, &notifications.Notification{
+			ID:         strconv.Itoa(i),
+			Reason:     "subscribed",
+			Repository: notifications.Repository{FullName: fmt.Sprintf("org/repo%d", i%20)},
+		})
+	}
+
+	m := &Manager{
+		config:        &config.Data{Rules: rules},
+		Actions:       actions.GetMap(nil),
+		Notifications: n,
+	}
+
+	for b.Loop() {
+		if err := m.Apply(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestApplySchedule(t *testing.T) {
+	t.Parallel()
+
+	// 2024-01-05 is a Friday.
+	m := &Manager{
+		config: &config.Data{Rules: []config.Rule{
+			{
+				Filters:  []string{`true`},
+				Action:   "tag",
+				Args:     []string{"weekend"},
+				Schedule: &config.Schedule{Days: []string{"sat", "sun"}},
+			},
+			{
+				Filters:  []string{`true`},
+				Action:   "tag",
+				Args:     []string{"friday"},
+				Schedule: &config.Schedule{Days: []string{"fri"}},
+			},
+		}},
+		Actions:       actions.GetMap(nil),
+		Notifications: notifications.Notifications{{ID: "0"}},
+		now: func() time.Time {
+			return time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+		},
+	}
+
+	if err := m.Apply(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(m.Notifications[0].Meta.Tags, []string{"friday"}) {
+		t.Errorf("want tags [friday], got %#v", m.Notifications[0].Meta.Tags)
+	}
+}
+
+func TestApplyLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		maxActions int
+		rules      []config.Rule
+		wantErr    bool
+		want       map[string][]string
+	}{
+		{
+			name: "under the limits",
+			rules: []config.Rule{
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"a"}, MaxPerRun: 2},
+			},
+			maxActions: 2,
+			want:       map[string][]string{"0": {"a"}, "1": {"a"}},
+		},
+		{
+			name: "over max_per_run",
+			rules: []config.Rule{
+				{Filters: []string{`.id == "0"`}, Action: "tag", Args: []string{"a"}},
+				{Filters: []string{`true`}, Action: "tag", Args: []string{"b"}, MaxPerRun: 1},
+			},
+			wantErr: true,
+			want:    map[string][]string{"0": {"a"}, "1": nil},
+		},
+		{
+			name: "over max_actions",
+			rules: []config.Rule{
+				{Filters: []string{`.id == "0"`}, Action: "tag", Args: []string{"a"}},
+				{
+					Filters: []string{`.id == "1"`},
+					Actions: []config.RuleAction{
+						{Name: "tag", Args: []string{"b"}},
+						{Name: "tag", Args: []string{"c"}},
+					},
+				},
+			},
+			maxActions: 2,
+			wantErr:    true,
+			want:       map[string][]string{"0": {"a"}, "1": nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Manager{
+				config: &config.Data{
+					Rules: test.rules,
+					Apply: config.Apply{MaxActions: test.maxActions},
+				},
+				Actions: actions.GetMap(nil),
+				Notifications: notifications.Notifications{
+					{ID: "0"},
+					{ID: "1"},
+				},
+			}
+
+			err := m.Apply()
+			if test.wantErr != errors.Is(err, errLimitExceeded) {
+				t.Fatalf("want error %v, got %v", test.wantErr, err)
+			}
+
+			for _, n := range m.Notifications {
+				if !slices.Equal(n.Meta.Tags, test.want[n.ID]) {
+					t.Errorf("notification %s: want tags %#v, got %#v", n.ID, test.want[n.ID], n.Meta.Tags)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/text"
+)
+
+// Card renders all the notification's details, followed by its history.
+func (n *Notification) Card() string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "%s\n%s\n\n", n.prettyTitle(), n.reference())
+
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{name: "Type", value: orNone(n.Subject.Type)},
+		{name: "State", value: orNone(n.Subject.State)},
+		{name: "Reason", value: orNone(n.Reason)},
+		{name: "Updated", value: fmt.Sprintf("%s (%s)",
+			n.UpdatedAt.Local().Format(time.DateTime), text.RelativeTimeAgo(time.Now(), n.UpdatedAt))},
+		{name: "Author", value: orNone(n.Author.Login)},
+		{name: "Assignees", value: logins(n.Assignees)},
+		{name: "Reviewers", value: logins(n.Reviewers)},
+		{name: "Teams", value: teams(n.ReviewersTeams)},
+		{name: "Merged by", value: orNone(n.MergedBy.Login)},
+		{name: "Tags", value: orNone(strings.Join(n.Meta.Tags, ", "))},
+		{name: "Status", value: n.status()},
+		{name: "URL", value: orNone(n.Subject.HTMLURL)},
+		{name: "API URL", value: orNone(n.Subject.URL)},
+		{name: "Thread", value: orNone(n.URL)},
+	}
+
+	for _, field := range fields {
+		fmt.Fprintf(w, "%s\t%s\n", field.name, field.value)
+	}
+
+	w.Flush()
+
+	if n.LatestComment != "" {
+		fmt.Fprintf(&out, "\nLatest comment by %s:\n  %s\n", orNone(n.LatestCommentor.Login), n.LatestComment)
+	}
+
+	out.WriteString("\nHistory:\n")
+
+	if len(n.Meta.History) == 0 {
+		out.WriteString("  " + none + "\n")
+	}
+
+	for _, e := range n.Meta.History {
+		out.WriteString("  " + e.String() + "\n")
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// reference returns the subject's `owner/repo#number`, or the repository if
+// the subject has no number.
+func (n *Notification) reference() string {
+	if r, ok := n.Ref(); ok {
+		return r.String()
+	}
+
+	return n.Repository.FullName
+}
+
+// status returns the notification's state and meta flags.
+func (n *Notification) status() string {
+	flags := []string{"read"}
+	if n.Unread {
+		flags[0] = "unread"
+	}
+
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{name: "done", set: n.Meta.Done},
+		{name: "hidden", set: n.Meta.Hidden},
+		{name: "enriched", set: n.Meta.Enriched},
+		{name: "remote", set: n.Meta.RemoteExists},
+	} {
+		if flag.set {
+			flags = append(flags, flag.name)
+		}
+	}
+
+	return strings.Join(flags, ", ")
+}
+
+func logins(users []User) string {
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Login)
+	}
+
+	return orNone(strings.Join(names, ", "))
+}
+
+func teams(teams []Team) string {
+	names := make([]string, 0, len(teams))
+	for _, t := range teams {
+		names = append(names, t.Name)
+	}
+
+	return orNone(strings.Join(names, ", "))
+}
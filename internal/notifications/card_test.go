@@ -0,0 +1,58 @@
+package notifications
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCard(t *testing.T) {
+	t.Parallel()
+
+	n := &Notification{
+		ID:         "0",
+		Unread:     true,
+		Reason:     "review_requested",
+		UpdatedAt:  time.Now(),
+		URL:        "https://api.github.com/notifications/threads/0",
+		Repository: Repository{FullName: "owner/repo"},
+		Subject: Subject{
+			Title:   "Add a feature",
+			URL:     "https://api.github.com/repos/owner/repo/pulls/12",
+			Type:    "PullRequest",
+			State:   "open",
+			HTMLURL: "https://github.com/owner/repo/pull/12",
+		},
+		Author:          User{Login: "author"},
+		Assignees:       []User{{Login: "a0"}, {Login: "a1"}},
+		ReviewersTeams:  []Team{{Name: "team"}},
+		LatestCommentor: User{Login: "commentor"},
+		LatestComment:   "Looks good",
+		Meta: Meta{
+			Enriched: true,
+			Tags:     []string{"t0", "t1"},
+			History:  []Event{{Time: time.Now(), Type: EventFirstSeen, Reason: "review_requested"}},
+		},
+	}
+
+	got := n.Card()
+
+	for _, want := range []string{
+		"Add a feature\nowner/repo#12\n",
+		"State      open\n",
+		"Reason     review_requested\n",
+		"Author     author\n",
+		"Assignees  a0, a1\n",
+		"Reviewers  (none)\n",
+		"Teams      team\n",
+		"Tags       t0, t1\n",
+		"Status     unread, enriched\n",
+		"URL        https://github.com/owner/repo/pull/12\n",
+		"Latest comment by commentor:\n  Looks good\n",
+		"first_seen review_requested",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q in\n%s", want, got)
+		}
+	}
+}
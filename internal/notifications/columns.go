@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/text"
+
+	"github.com/nobe4/gh-not/internal/colors"
+)
+
+var errUnknownColumn = errors.New("unknown column")
+
+// DefaultColumns are the built-in columns rendered when none is configured.
+//
+//nolint:gochecknoglobals // This is used as a default in a couple of places.
+var DefaultColumns = []string{"read", "type", "state", "repo", "author", "title", "time"}
+
+//nolint:gochecknoglobals // This map is used a lot.
+var builtinColumns = map[string]func(n *Notification) string{
+	"read":   (*Notification).prettyRead,
+	"type":   (*Notification).prettyType,
+	"state":  (*Notification).prettyState,
+	"repo":   func(n *Notification) string { return n.Repository.FullName },
+	"author": func(n *Notification) string { return n.Author.Login },
+	"title":  (*Notification).prettyTitle,
+	"time":   (*Notification).prettyTime,
+	"reason": func(n *Notification) string { return n.Reason },
+	"tags":   func(n *Notification) string { return strings.Join(n.Meta.Tags, ",") },
+	"id":     func(n *Notification) string { return n.ID },
+}
+
+// Column is a column of the rendered notifications.
+type Column struct {
+	// Name identifies the column, e.g. the built-in column's name. It's used
+	// as the header of the exported outputs when there's no Header.
+	Name string
+
+	// Header is the column's title, the header line is rendered only if a
+	// column has a title.
+	Header string
+
+	// Width is the maximum width of the column's values, 0 means no limit.
+	Width int
+
+	// Color is the color of the column's values, as accepted by lipgloss, e.g.
+	// `1` or `#ff0000`.
+	Color string
+
+	// Value returns the column's value for a notification.
+	Value func(n *Notification) string
+}
+
+// BuiltinColumn returns the built-in column with the name.
+func BuiltinColumn(name string) (Column, error) {
+	value, ok := builtinColumns[name]
+	if !ok {
+		return Column{}, fmt.Errorf("%w: %q, valid columns are %s",
+			errUnknownColumn, name, strings.Join(BuiltinColumnNames(), ", "))
+	}
+
+	return Column{Name: name, Value: value}, nil
+}
+
+// BuiltinColumnNames returns the names of the built-in columns, sorted.
+func BuiltinColumnNames() []string {
+	return slices.Sorted(maps.Keys(builtinColumns))
+}
+
+// defaultColumns returns the DefaultColumns.
+func defaultColumns() []Column {
+	columns := make([]Column, 0, len(DefaultColumns))
+
+	for _, name := range DefaultColumns {
+		column, _ := BuiltinColumn(name)
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+func (c Column) render(n *Notification) string {
+	value := c.Value(n)
+
+	if c.Width > 0 {
+		value = text.Truncate(c.Width, value)
+	}
+
+	if c.Color != "" {
+		value = colors.Colorize(c.Color, value)
+	}
+
+	return value
+}
+
+func (n *Notification) prettyTime() string {
+	relativeTime := text.RelativeTimeAgo(time.Now(), n.UpdatedAt)
+	if n.LatestCommentor.Login != "" {
+		relativeTime += " by " + n.LatestCommentor.Login
+	}
+
+	return relativeTime
+}
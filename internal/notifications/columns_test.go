@@ -0,0 +1,89 @@
+package notifications
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinColumn(t *testing.T) {
+	t.Parallel()
+
+	n := &Notification{ID: "0", Reason: "mention", Meta: Meta{Tags: []string{"a", "b"}}}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "id", want: "0"},
+		{name: "reason", want: "mention"},
+		{name: "tags", want: "a,b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			column, err := BuiltinColumn(test.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := column.Value(n); got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	if _, err := BuiltinColumn("nope"); !errors.Is(err, errUnknownColumn) {
+		t.Errorf("want %v, got %v", errUnknownColumn, err)
+	}
+}
+
+func TestRenderColumns(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{ID: "0", Reason: "mention"},
+		{ID: "1", Reason: "a very long reason"},
+	}
+
+	id, _ := BuiltinColumn("id")
+	reason, _ := BuiltinColumn("reason")
+	reason.Header = "Reason"
+	reason.Width = 6
+
+	// The tests don't run in a terminal, so this renders the simple strings.
+	header, _ := n.Render([]Column{id, reason})
+
+	if header != " Reason" {
+		t.Errorf("want header %q, got %q", " Reason", header)
+	}
+
+	want := []string{"0 men...", "1 a v..."}
+	for i, n := range n {
+		if n.String() != want[i] {
+			t.Errorf("want %q, got %q", want[i], n.String())
+		}
+	}
+}
+
+func TestRenderWithoutColumns(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{
+			Repository: Repository{FullName: "owner/repo"},
+			Author:     User{Login: "author"},
+			Subject:    Subject{Title: "a\ntitle"},
+		},
+	}
+
+	// The tests don't run in a terminal, so this renders the line format.
+	_, _ = n.Render(nil)
+
+	want := " owner/repo by author at "
+	if got := n[0].String(); !strings.Contains(got, want) || !strings.HasSuffix(got, ": 'a title'") {
+		t.Errorf("want the line format, got %q", got)
+	}
+}
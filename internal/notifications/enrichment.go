@@ -1,11 +1,18 @@
 package notifications
 
+import "time"
+
 // Update merges n into o, preserving enrichment if still fresh.
-func (n *Notification) Update(o *Notification) *Notification {
+// A newer o is recorded in the history, along with the transitions it causes.
+// The other transitions, e.g. a thread read on GitHub, which doesn't change
+// its update time, are recorded at now.
+func (n *Notification) Update(o *Notification, now time.Time) *Notification {
 	meta := n.Meta
 	meta.RemoteExists = true
 
-	if o.UpdatedAt.After(n.UpdatedAt) {
+	updated := o.UpdatedAt.After(n.UpdatedAt)
+
+	if updated {
 		meta.Done = false
 		meta.Enriched = false
 
@@ -16,12 +23,21 @@ func (n *Notification) Update(o *Notification) *Notification {
 
 	o.Meta = meta
 
+	switch {
+	case updated:
+		o.Record(Event{Time: o.UpdatedAt, Type: EventUpdated, Reason: o.Reason})
+		o.RecordTransitions(n.Status(), o.UpdatedAt)
+	case o.Status() != n.Status():
+		o.RecordTransitions(n.Status(), now)
+	}
+
 	return o
 }
 
 func (n *Notification) mergeEnrichment(o *Notification) {
 	n.Author = o.Author
 	n.LatestCommentor = o.LatestCommentor
+	n.LatestComment = o.LatestComment
 	n.Assignees = o.Assignees
 	n.Reviewers = o.Reviewers
 	n.ReviewersTeams = o.ReviewersTeams
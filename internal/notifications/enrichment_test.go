@@ -165,7 +165,7 @@ func TestMergeUpdatedNotification(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := tt.n.Update(tt.o)
+			got := tt.n.Update(tt.o, time.Time{})
 
 			if got != tt.o {
 				t.Fatal("expected returned notification to be o")
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/text"
+
+	"github.com/nobe4/gh-not/internal/colors"
+)
+
+// templateFuncs are the helper functions available in the templates.
+//
+//nolint:gochecknoglobals // This map is used a lot.
+var templateFuncs = template.FuncMap{
+	// ago returns the time relative to now, e.g. `{{ .UpdatedAt | ago }}`.
+	"ago": func(t time.Time) string { return text.RelativeTimeAgo(time.Now(), t) },
+
+	// color colors the string, e.g. `{{ .Reason | color "5" }}`.
+	"color": colors.Colorize,
+
+	// truncate shortens the string to a width, e.g. `{{ .Subject.Title | truncate 20 }}`.
+	"truncate": text.Truncate,
+
+	// join joins the strings, e.g. `{{ .Meta.Tags | join "," }}`.
+	"join": func(sep string, s []string) string { return strings.Join(s, sep) },
+}
+
+// NewTemplate parses a text/template for a single notification.
+// See templateFuncs for the available helper functions.
+func NewTemplate(text string) (*template.Template, error) {
+	t, err := template.New("format").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the template: %w", err)
+	}
+
+	return t, nil
+}
+
+// Format writes each notification with the template, one per line.
+func (n Notifications) Format(w io.Writer, t *template.Template) error {
+	for _, n := range n {
+		if err := t.Execute(w, n); err != nil {
+			return fmt.Errorf("failed to execute the template on %s: %w", n.ID, err)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{
+			ID:         "0",
+			UpdatedAt:  time.Now().Add(-2 * time.Hour),
+			Repository: Repository{FullName: "owner/repo"},
+			Subject:    Subject{Title: "a long title", URL: "https://api.github.com/repos/owner/repo/pulls/12"},
+			Meta:       Meta{Tags: []string{"a", "b"}},
+		},
+		{
+			ID:      "1",
+			Subject: Subject{Title: "short"},
+		},
+	}
+
+	tests := []struct {
+		template string
+		n        Notifications
+		want     string
+	}{
+		{
+			template: `{{.Repository.FullName}}#{{.Number}}`,
+			n:        n,
+			want:     "owner/repo#12\n#0\n",
+		},
+		{
+			template: `{{.Subject.Title | truncate 6}} {{.Meta.Tags | join "+"}}`,
+			n:        n,
+			want:     "a l... a+b\nshort \n",
+		},
+		{
+			template: `{{.UpdatedAt | ago}}`,
+			n:        n[:1],
+			want:     "about 2 hours ago\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := NewTemplate(test.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+
+			if err := test.n.Format(out, tmpl); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != test.want {
+				t.Errorf("want %q, got %q", test.want, out.String())
+			}
+		})
+	}
+
+	if _, err := NewTemplate(`{{`); err == nil {
+		t.Error("want an error for an invalid template")
+	}
+}
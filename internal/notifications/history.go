@@ -0,0 +1,136 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventType is the type of an Event in a notification's history.
+type EventType string
+
+const (
+	// EventFirstSeen is recorded when the notification is first fetched.
+	EventFirstSeen EventType = "first_seen"
+
+	// EventUpdated is recorded when the remote notification is updated.
+	EventUpdated EventType = "updated"
+
+	// EventAction is recorded when an action runs on the notification.
+	EventAction EventType = "action"
+
+	// The transitions of the notification's Status.
+	EventRead     EventType = "read"
+	EventUnread   EventType = "unread"
+	EventDone     EventType = "done"
+	EventUndone   EventType = "undone"
+	EventHidden   EventType = "hidden"
+	EventUnhidden EventType = "unhidden"
+)
+
+// Event is an entry of a notification's history, in Meta.History.
+// It can be queried with jq, e.g. `.meta.history[] | select(.type == "updated")`.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+
+	// Reason is the notification's reason, for EventFirstSeen and EventUpdated.
+	Reason string `json:"reason,omitempty"`
+
+	// Action, Args and Rule describe the action, for EventAction. Rule is empty
+	// for actions run manually.
+	Action string   `json:"action,omitempty"`
+	Args   []string `json:"args,omitempty"`
+	Rule   string   `json:"rule,omitempty"`
+
+	// Error is the action's error, for EventAction. It's empty if the action
+	// succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+func (e Event) String() string {
+	out := []string{e.Time.Local().Format(time.DateTime), string(e.Type)}
+
+	if e.Reason != "" {
+		out = append(out, e.Reason)
+	}
+
+	if e.Action != "" {
+		out = append(out, strings.Join(append([]string{e.Action}, e.Args...), " "))
+	}
+
+	if e.Rule != "" {
+		out = append(out, fmt.Sprintf("(rule %q)", e.Rule))
+	}
+
+	if e.Error != "" {
+		out = append(out, "failed: "+e.Error)
+	}
+
+	return strings.Join(out, " ")
+}
+
+// Status is the part of a notification whose transitions are recorded.
+type Status struct {
+	Unread bool
+	Done   bool
+	Hidden bool
+}
+
+// Status returns the notification's current Status.
+func (n *Notification) Status() Status {
+	return Status{Unread: n.Unread, Done: n.Meta.Done, Hidden: n.Meta.Hidden}
+}
+
+// Record appends the event to the notification's history.
+func (n *Notification) Record(e Event) {
+	n.Meta.History = append(n.Meta.History, e)
+}
+
+// RecordTransitions records the changes of the notification's Status since
+// before.
+func (n *Notification) RecordTransitions(before Status, t time.Time) {
+	after := n.Status()
+
+	transitions := []struct {
+		before, after bool
+		on, off       EventType
+	}{
+		{before: before.Unread, after: after.Unread, on: EventUnread, off: EventRead},
+		{before: before.Done, after: after.Done, on: EventDone, off: EventUndone},
+		{before: before.Hidden, after: after.Hidden, on: EventHidden, off: EventUnhidden},
+	}
+
+	for _, transition := range transitions {
+		switch {
+		case !transition.before && transition.after:
+			n.Record(Event{Time: t, Type: transition.on})
+		case transition.before && !transition.after:
+			n.Record(Event{Time: t, Type: transition.off})
+		}
+	}
+}
+
+// RecordAction records an action run on the notification, along with the
+// transitions it caused since before. A failed action is recorded with its
+// error.
+func (n *Notification) RecordAction(before Status, t time.Time, action string, args []string, rule string, err error) {
+	e := Event{Time: t, Type: EventAction, Action: action, Args: args, Rule: rule}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	n.Record(e)
+	n.RecordTransitions(before, t)
+}
+
+// TrimHistory keeps the last size events of each notification's history.
+func (n Notifications) TrimHistory(size int) {
+	size = max(size, 0)
+
+	for _, n := range n {
+		if n != nil && len(n.Meta.History) > size {
+			n.Meta.History = n.Meta.History[len(n.Meta.History)-size:]
+		}
+	}
+}
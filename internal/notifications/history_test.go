@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func eventTypes(n *Notification) []EventType {
+	types := []EventType{}
+	for _, e := range n.Meta.History {
+		types = append(types, e.Type)
+	}
+
+	return types
+}
+
+func TestSyncHistory(t *testing.T) {
+	t.Parallel()
+
+	local := &Notification{
+		ID:        "0",
+		Reason:    "mention",
+		UpdatedAt: time.Unix(1, 0),
+		Meta:      Meta{Done: true, History: []Event{{Time: time.Unix(1, 0), Type: EventFirstSeen}}},
+	}
+
+	got := Sync(
+		Notifications{local},
+		Notifications{
+			{ID: "0", Unread: true, Reason: "comment", UpdatedAt: time.Unix(2, 0)},
+			{ID: "1", Reason: "mention", UpdatedAt: time.Unix(3, 0)},
+		},
+		time.Unix(4, 0),
+	).Map()
+
+	want := []Event{
+		{Time: time.Unix(1, 0), Type: EventFirstSeen},
+		{Time: time.Unix(2, 0), Type: EventUpdated, Reason: "comment"},
+		{Time: time.Unix(2, 0), Type: EventUnread},
+		{Time: time.Unix(2, 0), Type: EventUndone},
+	}
+	if !reflect.DeepEqual(got["0"].Meta.History, want) {
+		t.Errorf("want %#v, got %#v", want, got["0"].Meta.History)
+	}
+
+	want = []Event{{Time: time.Unix(4, 0), Type: EventFirstSeen, Reason: "mention"}}
+	if !reflect.DeepEqual(got["1"].Meta.History, want) {
+		t.Errorf("want %#v, got %#v", want, got["1"].Meta.History)
+	}
+}
+
+func TestSyncHistoryRead(t *testing.T) {
+	t.Parallel()
+
+	local := &Notification{ID: "0", Unread: true, UpdatedAt: time.Unix(1, 0)}
+
+	got := Sync(
+		Notifications{local},
+		Notifications{{ID: "0", Unread: false, UpdatedAt: time.Unix(1, 0)}},
+		time.Unix(4, 0),
+	)
+
+	want := []Event{{Time: time.Unix(4, 0), Type: EventRead}}
+	if !reflect.DeepEqual(got[0].Meta.History, want) {
+		t.Errorf("want %#v, got %#v", want, got[0].Meta.History)
+	}
+
+	got = Sync(got, Notifications{{ID: "0", Unread: false, UpdatedAt: time.Unix(1, 0)}}, time.Unix(5, 0))
+
+	if !reflect.DeepEqual(got[0].Meta.History, want) {
+		t.Errorf("want no new event, got %#v", got[0].Meta.History)
+	}
+}
+
+func TestRecordAction(t *testing.T) {
+	t.Parallel()
+
+	n := &Notification{Unread: true}
+	before := n.Status()
+
+	n.Unread = false
+	n.Meta.Hidden = true
+
+	n.RecordAction(before, time.Unix(1, 0), "tag", []string{"a"}, "rule", nil)
+
+	if got, want := eventTypes(n), []EventType{EventAction, EventRead, EventHidden}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got, want := n.Meta.History[0].String(), `action tag a (rule "rule")`; !strings.HasSuffix(got, want) {
+		t.Errorf("want %q at the end of %q", want, got)
+	}
+}
+
+func TestRecordActionError(t *testing.T) {
+	t.Parallel()
+
+	n := &Notification{}
+	n.RecordAction(n.Status(), time.Unix(1, 0), "read", nil, "", errors.New("API error"))
+
+	if got := n.Meta.History[0].Error; got != "API error" {
+		t.Errorf("want the error to be recorded, got %q", got)
+	}
+
+	if got, want := n.Meta.History[0].String(), "action read failed: API error"; !strings.HasSuffix(got, want) {
+		t.Errorf("want %q at the end of %q", want, got)
+	}
+}
+
+func TestTrimHistory(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{Meta: Meta{History: []Event{{Type: EventFirstSeen}, {Type: EventUpdated}, {Type: EventRead}}}},
+		{Meta: Meta{History: []Event{{Type: EventFirstSeen}}}},
+		nil,
+	}
+
+	n.TrimHistory(2)
+
+	if got, want := eventTypes(n[0]), []EventType{EventUpdated, EventRead}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got, want := eventTypes(n[1]), []EventType{EventFirstSeen}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	n.TrimHistory(0)
+
+	if len(n[0].Meta.History) != 0 {
+		t.Errorf("want no history, got %v", n[0].Meta.History)
+	}
+}
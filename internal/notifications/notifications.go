@@ -33,6 +33,7 @@ type Notification struct {
 	// Enriched API fields
 	Author          User   `json:"author"`
 	LatestCommentor User   `json:"latest_commentor"`
+	LatestComment   string `json:"latest_comment"`
 	Assignees       []User `json:"assignees"`
 	Reviewers       []User `json:"requested_reviewers"`
 	ReviewersTeams  []Team `json:"requested_teams"`
@@ -65,6 +66,10 @@ type Meta struct {
 	// Tags is a list of tags that can be used to filter notifications.
 	// They can be added/removed with the `tag` action.
 	Tags []string `json:"tags"`
+
+	// History is the notification's timeline, oldest first.
+	// It's trimmed to the `cache.history_size` latest events when saved.
+	History []Event `json:"history"`
 }
 
 type Subject struct {
@@ -74,6 +79,14 @@ type Subject struct {
 	Type             string `json:"type"`
 	LatestCommentURL string `json:"latest_comment_url"`
 
+	// Number is the issue, pull request or discussion number, from the URL.
+	// It's 0 for the other subjects.
+	Number int `json:"number"`
+
+	// Kind is one of KindIssue, KindPull or KindDiscussion, from the URL.
+	// It's empty for the other subjects.
+	Kind string `json:"kind"`
+
 	// Enriched API fields
 	State   string `json:"state"`
 	HTMLURL string `json:"html_url"`
@@ -137,6 +150,7 @@ func (n *Notification) Equal(other *Notification) bool {
 		n.Author.Type == other.Author.Type &&
 		n.LatestCommentor.Login == other.LatestCommentor.Login &&
 		n.LatestCommentor.Type == other.LatestCommentor.Type &&
+		n.LatestComment == other.LatestComment &&
 		n.Meta.Hidden == other.Meta.Hidden &&
 		n.Meta.Done == other.Meta.Done &&
 		n.Meta.RemoteExists == other.Meta.RemoteExists &&
@@ -206,6 +220,37 @@ func (n Notifications) IDList() []string {
 	return ids
 }
 
+// Clone returns a deep copy of the notifications.
+func (n Notifications) Clone() Notifications {
+	c := make(Notifications, 0, len(n))
+	for _, n := range n {
+		c = append(c, n.Clone())
+	}
+
+	return c
+}
+
+// Clone returns a deep copy of the notification, e.g. to change it while the
+// original is in use.
+func (n *Notification) Clone() *Notification {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	c.Assignees = slices.Clone(n.Assignees)
+	c.Reviewers = slices.Clone(n.Reviewers)
+	c.ReviewersTeams = slices.Clone(n.ReviewersTeams)
+	c.Meta.Tags = slices.Clone(n.Meta.Tags)
+	c.Meta.History = slices.Clone(n.Meta.History)
+
+	for i := range c.Meta.History {
+		c.Meta.History[i].Args = slices.Clone(n.Meta.History[i].Args)
+	}
+
+	return &c
+}
+
 // Compact remove all nil notifications.
 // TODO: in-place update.
 func (n Notifications) Compact() Notifications {
@@ -214,16 +259,9 @@ func (n Notifications) Compact() Notifications {
 	})
 }
 
+// Sort sorts the notifications by update time, newest first.
 func (n Notifications) Sort() {
-	slices.SortFunc(n, func(a, b *Notification) int {
-		if a.UpdatedAt.Before(b.UpdatedAt) {
-			return 1
-		} else if a.UpdatedAt.After(b.UpdatedAt) {
-			return -1
-		}
-
-		return 0
-	})
+	n.SortBy(sortKeys["updated"], true)
 }
 
 // Uniq remove all duplicated notifications.
@@ -256,6 +294,18 @@ func (n Notifications) FilterFromIDs(ids []string) Notifications {
 	return newList
 }
 
+// Find returns the first notification identified by ref, or nil if none
+// matches. See Notification.Matches for the accepted references.
+func (n Notifications) Find(ref string) *Notification {
+	for _, n := range n {
+		if n != nil && n.Matches(ref) {
+			return n
+		}
+	}
+
+	return nil
+}
+
 func (n Notifications) Marshal() ([]byte, error) {
 	marshaled, err := json.Marshal(n)
 	if err != nil {
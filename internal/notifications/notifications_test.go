@@ -46,6 +46,40 @@ func TestCompact(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{
+			ID:        "0",
+			Assignees: []User{{Login: "a"}},
+			Meta: Meta{
+				Tags:    []string{"a"},
+				History: []Event{{Type: EventAction, Action: "tag", Args: []string{"a"}}},
+			},
+		},
+		nil,
+	}
+
+	got := n.Clone()
+
+	if len(got) != 2 || got[1] != nil {
+		t.Fatalf("want a copy with the nil notification, got %+v", got)
+	}
+
+	if got[0] == n[0] || !got[0].Equal(n[0]) {
+		t.Fatalf("want an equal copy, got %+v", got[0])
+	}
+
+	got[0].Assignees[0].Login = "b"
+	got[0].Meta.Tags[0] = "b"
+	got[0].Meta.History[0].Args[0] = "b"
+
+	if n[0].Assignees[0].Login != "a" || n[0].Meta.Tags[0] != "a" || n[0].Meta.History[0].Args[0] != "a" {
+		t.Errorf("want the original unchanged, got %+v", n[0])
+	}
+}
+
 func TestSort(t *testing.T) {
 	t.Parallel()
 
@@ -169,3 +203,46 @@ func TestFilterFromIds(t *testing.T) {
 		t.Fatalf("expected %+v but got %+v", n2, got[1])
 	}
 }
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	n0 := &Notification{ID: "0", URL: "https://api/threads/0"}
+	n1 := &Notification{
+		ID:         "1",
+		Repository: Repository{FullName: "owner/repo"},
+		Subject: Subject{
+			URL:     "https://api.github.com/repos/owner/repo/pulls/12",
+			HTMLURL: "https://github.com/owner/repo/pull/12",
+		},
+	}
+	n := Notifications{nil, n0, n1}
+
+	tests := []struct {
+		ref  string
+		want *Notification
+	}{
+		{"0", n0},
+		{"https://api/threads/0", n0},
+		{"1", n1},
+		{"https://api.github.com/repos/owner/repo/pulls/12", n1},
+		{"https://github.com/owner/repo/pull/12", n1},
+		{"https://github.com/Owner/Repo/pull/12/files#diff-0", n1},
+		{"https://github.com/owner/repo/issues/12#issuecomment-1", n1},
+		{"owner/repo#12", n1},
+		{"owner/repo#1", nil},
+		{"owner/repo", nil},
+		{"2", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			t.Parallel()
+
+			if got := n.Find(test.ref); got != test.want {
+				t.Fatalf("expected %#v but got %#v", test.want, got)
+			}
+		})
+	}
+}
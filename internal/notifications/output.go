@@ -0,0 +1,227 @@
+package notifications
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Output is an enum for the output format of the notifications list.
+// It implements https://pkg.go.dev/github.com/spf13/pflag#Value.
+type Output int
+
+const (
+	// OutputTable renders the notifications in a colored table.
+	OutputTable Output = iota
+
+	// OutputCSV writes the notifications as comma-separated values.
+	OutputCSV
+
+	// OutputTSV writes the notifications as tab-separated values.
+	OutputTSV
+
+	// OutputNDJSON writes one JSON object per notification and per line.
+	OutputNDJSON
+
+	// OutputMarkdown writes the notifications as a Markdown table.
+	OutputMarkdown
+)
+
+const (
+	outputTable    = "table"
+	outputCSV      = "csv"
+	outputTSV      = "tsv"
+	outputNDJSON   = "ndjson"
+	outputMarkdown = "markdown"
+	outputUnknown  = "unknown"
+)
+
+var errOutputNotAllowed = errors.New("not allowed")
+
+func (o *Output) String() string {
+	switch *o {
+	case OutputTable:
+		return outputTable
+	case OutputCSV:
+		return outputCSV
+	case OutputTSV:
+		return outputTSV
+	case OutputNDJSON:
+		return outputNDJSON
+	case OutputMarkdown:
+		return outputMarkdown
+	default:
+		return outputUnknown
+	}
+}
+
+func (*Output) Allowed() string {
+	return strings.Join([]string{outputTable, outputCSV, outputTSV, outputNDJSON, outputMarkdown}, ", ")
+}
+
+func (o *Output) Set(value string) error {
+	switch value {
+	case outputTable:
+		*o = OutputTable
+	case outputCSV:
+		*o = OutputCSV
+	case outputTSV:
+		*o = OutputTSV
+	case outputNDJSON:
+		*o = OutputNDJSON
+	case outputMarkdown:
+		*o = OutputMarkdown
+	default:
+		return fmt.Errorf(`%s must be one of %s: %w`, value, o.Allowed(), errOutputNotAllowed)
+	}
+
+	return nil
+}
+
+func (*Output) Type() string {
+	return "Output"
+}
+
+// Write writes the notifications in the output format, with the columns or
+// the DefaultColumns if there's none.
+// The values are written in full and without colors. The headers are the
+// columns' Header, or their Name if they don't have one.
+// OutputTable is not supported here, see Render.
+func (n Notifications) Write(w io.Writer, o Output, columns []Column) error {
+	if len(columns) == 0 {
+		columns = defaultColumns()
+	}
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.title(i)
+	}
+
+	rows := make([][]string, len(n))
+	for i, n := range n {
+		rows[i] = make([]string, len(columns))
+		for j, c := range columns {
+			rows[i][j] = c.plain(n)
+		}
+	}
+
+	switch o {
+	case OutputCSV:
+		return writeCSV(w, ',', headers, rows)
+	case OutputTSV:
+		return writeTSV(w, headers, rows)
+	case OutputNDJSON:
+		return writeNDJSON(w, headers, rows)
+	case OutputMarkdown:
+		return writeMarkdown(w, headers, rows)
+	default:
+		return fmt.Errorf("%s: %w", o.String(), errOutputNotAllowed)
+	}
+}
+
+// title returns the column's header for the exported outputs.
+func (c Column) title(i int) string {
+	if c.Header != "" {
+		return c.Header
+	}
+
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return fmt.Sprintf("column %d", i)
+}
+
+// plain returns the column's full value, without colors.
+func (c Column) plain(n *Notification) string {
+	return ansi.Strip(c.Value(n))
+}
+
+func writeCSV(w io.Writer, comma rune, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(headers); err != nil {
+		return fmt.Errorf("failed to write the headers: %w", err)
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write the rows: %w", err)
+	}
+
+	return nil
+}
+
+// writeTSV writes the values as-is, replacing the tabs and new lines that
+// would break the format with spaces.
+func writeTSV(w io.Writer, headers []string, rows [][]string) error {
+	r := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, value := range row {
+			row[i] = r.Replace(value)
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return fmt.Errorf("failed to write the row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeNDJSON writes an object per row, with the headers as keys in the
+// columns order.
+func writeNDJSON(w io.Writer, headers []string, rows [][]string) error {
+	for _, row := range rows {
+		fields := make([]string, len(row))
+
+		for i, value := range row {
+			key, err := json.Marshal(headers[i])
+			if err != nil {
+				return fmt.Errorf("failed to marshal the header: %w", err)
+			}
+
+			val, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal the value: %w", err)
+			}
+
+			fields[i] = string(key) + ":" + string(val)
+		}
+
+		if _, err := fmt.Fprintf(w, "{%s}\n", strings.Join(fields, ",")); err != nil {
+			return fmt.Errorf("failed to write the row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeMarkdown writes a GitHub-flavored Markdown table, escaping the pipes
+// and replacing the new lines in the values.
+func writeMarkdown(w io.Writer, headers []string, rows [][]string) error {
+	r := strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ")
+	separators := make([]string, len(headers))
+
+	for i := range separators {
+		separators[i] = "---"
+	}
+
+	for _, row := range append([][]string{headers, separators}, rows...) {
+		for i, value := range row {
+			row[i] = r.Replace(value)
+		}
+
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | ")); err != nil {
+			return fmt.Errorf("failed to write the row: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package notifications
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{ID: "0", Reason: "mention", Subject: Subject{Type: "Issue", Title: "a, \"b\" | c"}},
+		{ID: "1", Reason: "review_requested", Subject: Subject{Type: "PullRequest", Title: "tab\there"}},
+	}
+
+	id, _ := BuiltinColumn("id")
+	kind, _ := BuiltinColumn("type")
+	title, _ := BuiltinColumn("title")
+	title.Header = "Title"
+	title.Width = 3
+	title.Color = "1"
+	columns := []Column{id, kind, title}
+
+	tests := []struct {
+		output Output
+		want   string
+	}{
+		{
+			output: OutputCSV,
+			want: `id,type,Title
+0,IS,"a, ""b"" | c"
+1,PR,tab	here
+`,
+		},
+		{
+			output: OutputTSV,
+			want:   "id\ttype\tTitle\n0\tIS\ta, \"b\" | c\n1\tPR\ttab here\n",
+		},
+		{
+			output: OutputNDJSON,
+			want: `{"id":"0","type":"IS","Title":"a, \"b\" | c"}
+{"id":"1","type":"PR","Title":"tab\there"}
+`,
+		},
+		{
+			output: OutputMarkdown,
+			want: `| id | type | Title |
+| --- | --- | --- |
+| 0 | IS | a, "b" \| c |
+| 1 | PR | tab	here |
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.output.String(), func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			if err := n.Write(&out, test.output, columns); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != test.want {
+				t.Errorf("want\n%s\ngot\n%s", test.want, out.String())
+			}
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		if err := n.Write(&bytes.Buffer{}, OutputTable, columns); !errors.Is(err, errOutputNotAllowed) {
+			t.Errorf("want %v, got %v", errOutputNotAllowed, err)
+		}
+	})
+}
+
+func TestOutputSet(t *testing.T) {
+	t.Parallel()
+
+	var o Output
+
+	if err := o.Set("markdown"); err != nil || o != OutputMarkdown {
+		t.Errorf("want %v, got %v with error %v", OutputMarkdown, o, err)
+	}
+
+	if err := o.Set("xml"); !errors.Is(err, errOutputNotAllowed) {
+		t.Errorf("want %v, got %v", errOutputNotAllowed, err)
+	}
+}
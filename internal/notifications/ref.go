@@ -0,0 +1,165 @@
+package notifications
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var errInvalidRef = errors.New("invalid reference")
+
+// Kinds of the subjects that have a number.
+const (
+	KindIssue      = "issue"
+	KindPull       = "pull"
+	KindDiscussion = "discussion"
+)
+
+//nolint:gochecknoglobals // This map is used a lot.
+var kinds = map[string]string{
+	"issues":      KindIssue,
+	"pull":        KindPull,
+	"pulls":       KindPull,
+	"discussions": KindDiscussion,
+}
+
+// Ref identifies an issue, pull request or discussion.
+type Ref struct {
+	Owner  string
+	Repo   string
+	Number int
+
+	// Kind is one of KindIssue, KindPull or KindDiscussion. It's empty when
+	// parsed from `owner/repo#number`.
+	Kind string
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// Is returns true if both references point to the same number in the same
+// repository.
+// Issues and pull requests share their numbers, so the kind is ignored.
+func (r Ref) Is(other Ref) bool {
+	return r.Number == other.Number &&
+		strings.EqualFold(r.Owner, other.Owner) &&
+		strings.EqualFold(r.Repo, other.Repo)
+}
+
+// ParseRef parses a reference from:
+//   - `owner/repo#number`
+//   - an HTML URL, e.g. `https://github.com/owner/repo/pull/1/files`
+//   - an API URL, e.g. `https://api.github.com/repos/owner/repo/pulls/1`
+//
+// The URLs can be from any host.
+func ParseRef(s string) (Ref, error) {
+	if repo, number, ok := strings.Cut(s, "#"); ok && !strings.Contains(s, "://") {
+		owner, name, ok := strings.Cut(repo, "/")
+		n, err := strconv.Atoi(number)
+
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") || err != nil || n <= 0 {
+			return Ref{}, fmt.Errorf("%w: %q", errInvalidRef, s)
+		}
+
+		return Ref{Owner: owner, Repo: name, Number: n}, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return Ref{}, fmt.Errorf("%w: %q", errInvalidRef, s)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	// API URLs are `/repos/...` on github.com and `/api/v3/repos/...` on
+	// GitHub Enterprise.
+	switch {
+	case strings.HasPrefix(u.Host, "api.") && segments[0] == "repos":
+		segments = segments[1:]
+	case len(segments) > 3 && slices.Equal(segments[:3], []string{"api", "v3", "repos"}):
+		segments = segments[3:]
+	}
+
+	if len(segments) < 4 {
+		return Ref{}, fmt.Errorf("%w: %q", errInvalidRef, s)
+	}
+
+	kind, ok := kinds[segments[2]]
+	n, err := strconv.Atoi(segments[3])
+
+	if !ok || err != nil || n <= 0 {
+		return Ref{}, fmt.Errorf("%w: %q", errInvalidRef, s)
+	}
+
+	return Ref{Owner: segments[0], Repo: segments[1], Number: n, Kind: kind}, nil
+}
+
+// Ref returns the reference of the notification's subject, and false if the
+// subject has none, e.g. for a release.
+func (n *Notification) Ref() (Ref, bool) {
+	r, err := ParseRef(n.Subject.URL)
+	if err != nil {
+		return Ref{}, false
+	}
+
+	return r, true
+}
+
+// Number returns the issue, pull request or discussion number of the subject,
+// or 0 if the subject doesn't have one.
+func (n *Notification) Number() int {
+	if n.Subject.Number != 0 {
+		return n.Subject.Number
+	}
+
+	r, _ := n.Ref()
+
+	return r.Number
+}
+
+// Matches returns true if the notification is identified by ref, which can be
+// the notification's ID, its thread URL, its subject's URL, or any reference
+// accepted by ParseRef.
+func (n *Notification) Matches(ref string) bool {
+	if ref == "" {
+		return false
+	}
+
+	if n.ID == ref || n.URL == ref || n.Subject.URL == ref || n.Subject.HTMLURL == ref {
+		return true
+	}
+
+	parsed, err := ParseRef(ref)
+	if err != nil {
+		return false
+	}
+
+	own, ok := n.Ref()
+
+	return ok && own.Is(parsed)
+}
+
+// UnmarshalJSON sets the Number and Kind from the URL if they're missing, for
+// the notifications coming from the API and older caches.
+func (s *Subject) UnmarshalJSON(data []byte) error {
+	type subject Subject
+
+	if err := json.Unmarshal(data, (*subject)(s)); err != nil {
+		return fmt.Errorf("failed to unmarshal subject: %w", err)
+	}
+
+	if s.Number == 0 || s.Kind == "" {
+		if r, err := ParseRef(s.URL); err == nil {
+			s.Number = cmp.Or(s.Number, r.Number)
+			s.Kind = cmp.Or(s.Kind, r.Kind)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref  string
+		want Ref
+	}{
+		{ref: "owner/repo#1", want: Ref{Owner: "owner", Repo: "repo", Number: 1}},
+		{
+			ref:  "https://github.com/owner/repo/pull/2",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 2, Kind: KindPull},
+		},
+		{
+			ref:  "https://github.com/owner/repo/pull/2/files?w=1#diff",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 2, Kind: KindPull},
+		},
+		{
+			ref:  "https://github.com/owner/repo/issues/3#issuecomment-4",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 3, Kind: KindIssue},
+		},
+		{
+			ref:  "https://github.com/owner/repo/discussions/5",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 5, Kind: KindDiscussion},
+		},
+		{
+			ref:  "https://api.github.com/repos/owner/repo/pulls/6",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 6, Kind: KindPull},
+		},
+		{
+			ref:  "https://ghe.io/api/v3/repos/owner/repo/issues/7",
+			want: Ref{Owner: "owner", Repo: "repo", Number: 7, Kind: KindIssue},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRef(test.ref)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+
+	for _, ref := range []string{
+		"",
+		"123",
+		"owner/repo",
+		"owner#1",
+		"owner/repo#x",
+		"owner/repo/x#1",
+		"https://github.com/owner/repo",
+		"https://github.com/owner/repo/commit/abc",
+		"https://api.github.com/repos/owner/repo/releases/1",
+	} {
+		t.Run("invalid "+ref, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := ParseRef(ref); !errors.Is(err, errInvalidRef) {
+				t.Errorf("want %v, got %v", errInvalidRef, err)
+			}
+		})
+	}
+}
+
+func TestSubjectUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data     string
+		want     int
+		wantKind string
+	}{
+		{data: `{"url": "https://api.github.com/repos/owner/repo/pulls/12"}`, want: 12, wantKind: KindPull},
+		{data: `{"url": "https://api.github.com/repos/owner/repo/issues/12"}`, want: 12, wantKind: KindIssue},
+		{
+			data:     `{"url": "https://api.github.com/repos/owner/repo/pulls/12", "number": 3, "kind": "issue"}`,
+			want:     3,
+			wantKind: KindIssue,
+		},
+		{data: `{"url": "https://api.github.com/repos/owner/repo/releases/12"}`, want: 0},
+		{data: `{}`, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			t.Parallel()
+
+			s := Subject{}
+			if err := json.Unmarshal([]byte(test.data), &s); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if s.Number != test.want {
+				t.Errorf("want %d, got %d", test.want, s.Number)
+			}
+
+			if s.Kind != test.wantKind {
+				t.Errorf("want kind %q, got %q", test.wantKind, s.Kind)
+			}
+		})
+	}
+}
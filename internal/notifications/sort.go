@@ -0,0 +1,133 @@
+package notifications
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var errUnknownGroupKey = errors.New("unknown group key")
+
+// none stands for a missing value, e.g. in the card, or as the name of the
+// group of notifications without a value for the group key.
+const none = "(none)"
+
+//nolint:gochecknoglobals // This map is used a lot.
+var sortKeys = map[string]func(a, b *Notification) int{
+	"updated": func(a, b *Notification) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	"repo":    func(a, b *Notification) int { return cmp.Compare(a.Repository.FullName, b.Repository.FullName) },
+	"reason":  func(a, b *Notification) int { return cmp.Compare(a.Reason, b.Reason) },
+	"type":    func(a, b *Notification) int { return cmp.Compare(a.Subject.Type, b.Subject.Type) },
+	"author":  func(a, b *Notification) int { return cmp.Compare(a.Author.Login, b.Author.Login) },
+}
+
+//nolint:gochecknoglobals // This map is used a lot.
+var groupKeys = map[string]func(n *Notification) []string{
+	"repo":   func(n *Notification) []string { return []string{n.Repository.FullName} },
+	"reason": func(n *Notification) []string { return []string{n.Reason} },
+	"type":   func(n *Notification) []string { return []string{n.Subject.Type} },
+	"tag":    func(n *Notification) []string { return n.Meta.Tags },
+}
+
+// SortCompare returns the comparison function of the built-in sort key, and
+// whether it exists.
+func SortCompare(key string) (func(a, b *Notification) int, bool) {
+	compare, ok := sortKeys[key]
+
+	return compare, ok
+}
+
+// SortKeyNames returns the names of the built-in sort keys, sorted.
+func SortKeyNames() []string {
+	return slices.Sorted(maps.Keys(sortKeys))
+}
+
+// GroupKeyNames returns the names of the group keys, sorted.
+func GroupKeyNames() []string {
+	return slices.Sorted(maps.Keys(groupKeys))
+}
+
+// SortBy sorts the notifications in place with the comparison function, in
+// descending order if desc is true.
+// Equal notifications keep their order.
+func (n Notifications) SortBy(compare func(a, b *Notification) int, desc bool) {
+	slices.SortStableFunc(n, func(a, b *Notification) int {
+		if desc {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+}
+
+// Group is a named list of notifications.
+type Group struct {
+	Name          string
+	Notifications Notifications
+}
+
+// Groups is a list of Group.
+type Groups []Group
+
+// GroupBy groups the notifications by the key, see GroupKeyNames.
+// The groups are in order of first appearance and keep the notifications'
+// order. A notification with multiple tags appears in each tag's group.
+func (n Notifications) GroupBy(key string) (Groups, error) {
+	values, ok := groupKeys[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, valid keys are %s",
+			errUnknownGroupKey, key, strings.Join(GroupKeyNames(), ", "))
+	}
+
+	groups := Groups{}
+	indexes := map[string]int{}
+
+	for _, notification := range n {
+		names := values(notification)
+		if len(names) == 0 {
+			names = []string{""}
+		}
+
+		for _, name := range names {
+			if name == "" {
+				name = none
+			}
+
+			i, ok := indexes[name]
+			if !ok {
+				i = len(groups)
+				indexes[name] = i
+
+				groups = append(groups, Group{Name: name})
+			}
+
+			groups[i].Notifications = append(groups[i].Notifications, notification)
+		}
+	}
+
+	return groups, nil
+}
+
+// Flatten returns the groups' notifications in order, without duplicates.
+func (g Groups) Flatten() Notifications {
+	n := Notifications{}
+	for _, group := range g {
+		n = append(n, group.Notifications...)
+	}
+
+	return n.Uniq()
+}
+
+// String renders each group as a section with its name and count, followed by
+// its rendered notifications.
+func (g Groups) String() string {
+	sections := make([]string, 0, len(g))
+	for _, group := range g {
+		sections = append(sections, fmt.Sprintf("%s (%d)\n%s", group.Name, len(group.Notifications), group.Notifications))
+	}
+
+	return strings.Join(sections, "\n")
+}
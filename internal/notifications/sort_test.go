@@ -0,0 +1,121 @@
+package notifications
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		key  string
+		desc bool
+		want []string
+	}{
+		{key: "updated", desc: true, want: []string{"1", "2", "0"}},
+		{key: "updated", want: []string{"0", "2", "1"}},
+		{key: "repo", want: []string{"1", "0", "2"}},
+		{key: "repo", desc: true, want: []string{"0", "2", "1"}},
+		{key: "author", want: []string{"2", "0", "1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			t.Parallel()
+
+			n := Notifications{
+				{ID: "0", UpdatedAt: now.Add(-2 * time.Hour), Repository: Repository{FullName: "b"}, Author: User{Login: "y"}},
+				{ID: "1", UpdatedAt: now, Repository: Repository{FullName: "a"}, Author: User{Login: "z"}},
+				{ID: "2", UpdatedAt: now.Add(-time.Hour), Repository: Repository{FullName: "b"}, Author: User{Login: "x"}},
+			}
+
+			compare, ok := SortCompare(test.key)
+			if !ok {
+				t.Fatalf("unknown key %q", test.key)
+			}
+
+			n.SortBy(compare, test.desc)
+
+			if got := n.IDList(); !slices.Equal(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	n := Notifications{
+		{ID: "0", Reason: "mention", Meta: Meta{Tags: []string{"a", "b"}}},
+		{ID: "1", Reason: "review_requested"},
+		{ID: "2", Reason: "mention", Meta: Meta{Tags: []string{"b"}}},
+	}
+
+	tests := []struct {
+		key     string
+		want    []string
+		wantIDs [][]string
+	}{
+		{
+			key:     "reason",
+			want:    []string{"mention", "review_requested"},
+			wantIDs: [][]string{{"0", "2"}, {"1"}},
+		},
+		{
+			key:     "tag",
+			want:    []string{"a", "b", none},
+			wantIDs: [][]string{{"0"}, {"0", "2"}, {"1"}},
+		},
+		{
+			key:     "repo",
+			want:    []string{none},
+			wantIDs: [][]string{{"0", "1", "2"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			t.Parallel()
+
+			groups, err := n.GroupBy(test.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(groups) != len(test.want) {
+				t.Fatalf("want %d groups, got %#v", len(test.want), groups)
+			}
+
+			for i, group := range groups {
+				if group.Name != test.want[i] || !slices.Equal(group.Notifications.IDList(), test.wantIDs[i]) {
+					t.Errorf("want %s with %v, got %s with %v",
+						test.want[i], test.wantIDs[i], group.Name, group.Notifications.IDList())
+				}
+			}
+		})
+	}
+
+	t.Run("flatten", func(t *testing.T) {
+		t.Parallel()
+
+		groups, _ := n.GroupBy("tag")
+
+		if got, want := groups.Flatten().IDList(), []string{"0", "2", "1"}; !slices.Equal(got, want) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := n.GroupBy("nope"); !errors.Is(err, errUnknownGroupKey) {
+			t.Errorf("want %v, got %v", errUnknownGroupKey, err)
+		}
+	})
+}
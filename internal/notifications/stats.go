@@ -0,0 +1,185 @@
+package notifications
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ageBuckets are the upper bounds of the age buckets, the last bucket holds
+// the older notifications.
+//
+//nolint:gochecknoglobals // This is a static list.
+var ageBuckets = []struct {
+	name string
+	max  time.Duration
+}{
+	{name: "< 1 day", max: 24 * time.Hour},
+	{name: "1-7 days", max: 7 * 24 * time.Hour},
+	{name: "1-4 weeks", max: 28 * 24 * time.Hour},
+}
+
+const ageOlder = "> 4 weeks"
+
+// Count is the number of notifications with a value.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Stats holds the aggregated counts of a list of notifications.
+type Stats struct {
+	// Time is when the stats were computed.
+	Time time.Time `json:"time"`
+
+	Total      int `json:"total"`
+	Unread     int `json:"unread"`
+	Done       int `json:"done"`
+	Hidden     int `json:"hidden"`
+	Unenriched int `json:"unenriched"`
+
+	// The breakdowns are sorted by decreasing count, except Age which is
+	// sorted by increasing age.
+	Reason     []Count `json:"reason"`
+	Repository []Count `json:"repository"`
+	Type       []Count `json:"type"`
+	State      []Count `json:"state"`
+	Author     []Count `json:"author"`
+	Tag        []Count `json:"tag"`
+	Age        []Count `json:"age"`
+}
+
+// Stats computes the notifications' counts, with the age relative to now.
+// A notification without value, e.g. without tags, is counted under `(none)`.
+//
+//revive:disable:cognitive-complexity // TODO: simplify.
+func (n Notifications) Stats(now time.Time) Stats {
+	s := Stats{Time: now, Total: len(n)}
+
+	reasons := map[string]int{}
+	repositories := map[string]int{}
+	types := map[string]int{}
+	states := map[string]int{}
+	authors := map[string]int{}
+	tags := map[string]int{}
+	ages := map[string]int{}
+
+	for _, n := range n {
+		if n.Unread {
+			s.Unread++
+		}
+
+		if n.Meta.Done {
+			s.Done++
+		}
+
+		if n.Meta.Hidden {
+			s.Hidden++
+		}
+
+		if !n.Meta.Enriched {
+			s.Unenriched++
+		}
+
+		reasons[orNone(n.Reason)]++
+		repositories[orNone(n.Repository.FullName)]++
+		types[orNone(n.Subject.Type)]++
+		states[orNone(n.Subject.State)]++
+		authors[orNone(n.Author.Login)]++
+		ages[ageBucket(now.Sub(n.UpdatedAt))]++
+
+		if len(n.Meta.Tags) == 0 {
+			tags[none]++
+		}
+
+		for _, tag := range n.Meta.Tags {
+			tags[tag]++
+		}
+	}
+
+	s.Reason = counts(reasons)
+	s.Repository = counts(repositories)
+	s.Type = counts(types)
+	s.State = counts(states)
+	s.Author = counts(authors)
+	s.Tag = counts(tags)
+
+	s.Age = []Count{}
+	for _, bucket := range ageBuckets {
+		s.Age = append(s.Age, Count{Name: bucket.name, Count: ages[bucket.name]})
+	}
+
+	s.Age = append(s.Age, Count{Name: ageOlder, Count: ages[ageOlder]})
+
+	return s
+}
+
+func (s Stats) String() string {
+	var out strings.Builder
+
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "total\t%d\nunread\t%d\ndone\t%d\nhidden\t%d\nunenriched\t%d\n",
+		s.Total, s.Unread, s.Done, s.Hidden, s.Unenriched)
+
+	sections := []struct {
+		name   string
+		counts []Count
+	}{
+		{name: "reason", counts: s.Reason},
+		{name: "repository", counts: s.Repository},
+		{name: "type", counts: s.Type},
+		{name: "state", counts: s.State},
+		{name: "author", counts: s.Author},
+		{name: "tag", counts: s.Tag},
+		{name: "age", counts: s.Age},
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(w, "\n%s\n", section.name)
+
+		for _, c := range section.counts {
+			fmt.Fprintf(w, "  %s\t%d\n", c.Name, c.Count)
+		}
+	}
+
+	w.Flush()
+
+	return out.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return none
+	}
+
+	return s
+}
+
+func ageBucket(age time.Duration) string {
+	for _, bucket := range ageBuckets {
+		if age < bucket.max {
+			return bucket.name
+		}
+	}
+
+	return ageOlder
+}
+
+// counts returns the counts sorted by decreasing count, then by name.
+func counts(m map[string]int) []Count {
+	c := []Count{}
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		c = append(c, Count{Name: name, Count: m[name]})
+	}
+
+	slices.SortStableFunc(c, func(a, b Count) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return c
+}
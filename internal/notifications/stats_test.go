@@ -0,0 +1,67 @@
+package notifications
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	n := Notifications{
+		{
+			Unread:     true,
+			Reason:     "mention",
+			UpdatedAt:  now.Add(-time.Hour),
+			Repository: Repository{FullName: "a/b"},
+			Subject:    Subject{Type: "Issue", State: "open"},
+			Author:     User{Login: "x"},
+			Meta:       Meta{Tags: []string{"t0", "t1"}, Enriched: true},
+		},
+		{
+			Reason:     "review_requested",
+			UpdatedAt:  now.Add(-3 * 24 * time.Hour),
+			Repository: Repository{FullName: "a/b"},
+			Subject:    Subject{Type: "PullRequest"},
+			Meta:       Meta{Done: true, Tags: []string{"t1"}},
+		},
+		{
+			Reason:     "mention",
+			UpdatedAt:  now.Add(-60 * 24 * time.Hour),
+			Repository: Repository{FullName: "c/d"},
+			Subject:    Subject{Type: "Issue", State: "closed"},
+			Author:     User{Login: "y"},
+			Meta:       Meta{Hidden: true},
+		},
+	}
+
+	got := n.Stats(now)
+
+	want := Stats{
+		Time:       now,
+		Total:      3,
+		Unread:     1,
+		Done:       1,
+		Hidden:     1,
+		Unenriched: 2,
+		Reason:     []Count{{"mention", 2}, {"review_requested", 1}},
+		Repository: []Count{{"a/b", 2}, {"c/d", 1}},
+		Type:       []Count{{"Issue", 2}, {"PullRequest", 1}},
+		State:      []Count{{none, 1}, {"closed", 1}, {"open", 1}},
+		Author:     []Count{{none, 1}, {"x", 1}, {"y", 1}},
+		Tag:        []Count{{"t1", 2}, {none, 1}, {"t0", 1}},
+		Age:        []Count{{"< 1 day", 1}, {"1-7 days", 1}, {"1-4 weeks", 0}, {"> 4 weeks", 1}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	if out := got.String(); !strings.Contains(out, "unenriched  2") || !strings.Contains(out, "  t1      2") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
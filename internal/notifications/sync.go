@@ -1,6 +1,9 @@
 package notifications
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+)
 
 /*
 Sync merges the local and remote notifications.
@@ -12,9 +15,10 @@ It applies the following rules:
 	| Exist          | (1) Insert | (2) Update | (2) Update | (3) Keep |
 	| Missing        |            | (3) Keep   | (4) Drop   | (4) Drop |
 
-	(1) Insert: Add the notification ass is.
+	(1) Insert: Add the notification ass is, recording it as first seen at now.
 	(2) Update: Update the local notification with the remote data, keep the Meta
-	    unchanged.
+	    unchanged. A newer remote notification is recorded in the history, as
+	    are the other changes of status, e.g. read on GitHub, at now.
 	(3) Keep: Keep the local notification unchanged.
 	(4) Drop: Remove the notification from the local list.
 
@@ -25,7 +29,7 @@ TODO: refactor this to `func (n Notifications) Sync(remote Notifications) {}`.
 */
 //revive:disable:cognitive-complexity // There's enough comments/details to keep
 // it all here.
-func Sync(local, remote Notifications) Notifications {
+func Sync(local, remote Notifications, now time.Time) Notifications {
 	// TODO: do we need to have the whole map?
 	remoteMap := remote.Map()
 	localMap := local.Map()
@@ -39,6 +43,7 @@ func Sync(local, remote Notifications) Notifications {
 			slog.Debug("sync", "action", "insert", "id", remote[i].ID)
 
 			remote[i].Meta.RemoteExists = true
+			remote[i].Record(Event{Time: now, Type: EventFirstSeen, Reason: remote[i].Reason})
 			n = append(n, remote[i])
 		}
 	}
@@ -60,7 +65,7 @@ func Sync(local, remote Notifications) Notifications {
 			// (2) Update
 			slog.Debug("sync", "action", "update", "id", remote.ID)
 
-			n = append(n, local[i].Update(remote))
+			n = append(n, local[i].Update(remote, now))
 		} else {
 			if local[i].Meta.Done || local[i].Meta.Hidden {
 				// (4) Drop
@@ -85,7 +85,7 @@ func TestSync(t *testing.T) {
 	// parallel.
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := Sync(test.local, test.remote)
+			got := Sync(test.local, test.remote, time.Time{})
 
 			if len(got) != len(test.expected) {
 				t.Fatalf("expected %d notifications but got %d", len(test.expected), len(got))
@@ -111,6 +111,7 @@ func TestSync(t *testing.T) {
 				&Notification{ID: "0", UpdatedAt: time.Unix(0, 2)},
 				&Notification{ID: "2", UpdatedAt: time.Unix(0, 0)},
 			},
+			time.Time{},
 		)
 
 		if !got[0].Meta.RemoteExists {
@@ -164,7 +165,7 @@ func TestSync(t *testing.T) {
 			t.Run(test.name, func(t *testing.T) {
 				t.Parallel()
 
-				got := Sync(Notifications{test.local}, Notifications{test.remote})
+				got := Sync(Notifications{test.local}, Notifications{test.remote}, time.Time{})
 				if got[0].Meta.Done != test.expectedDone {
 					t.Fatalf("expected Done to be %v but got %v", test.expectedDone, got[0].Meta.Done)
 				}
@@ -188,7 +189,7 @@ func TestSyncPreservesCachedEnrichmentOnUpdate(t *testing.T) {
 		Subject:   Subject{URL: "remote-url"},
 	}
 
-	got := Sync(Notifications{local}, Notifications{remote})
+	got := Sync(Notifications{local}, Notifications{remote}, time.Time{})
 
 	if !got[0].Meta.Enriched {
 		t.Fatal("expected Enriched to be preserved")
@@ -214,7 +215,7 @@ func TestSyncResetsCachedEnrichmentForNewerRemote(t *testing.T) {
 	}
 	remote := &Notification{ID: "0", UpdatedAt: time.Unix(0, 2)}
 
-	got := Sync(Notifications{local}, Notifications{remote})
+	got := Sync(Notifications{local}, Notifications{remote}, time.Time{})
 
 	if got[0].Meta.Enriched {
 		t.Fatal("expected Enriched to be reset for newer remote notification")
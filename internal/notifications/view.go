@@ -65,6 +65,21 @@ func (n *Notification) prettyState() string {
 	return colors.Yellow("S?")
 }
 
+// line is the simple string used when the table can't be rendered and no
+// columns are set.
+func (n *Notification) line() string {
+	return fmt.Sprintf(
+		"%s %s %s %s by %s at %s: '%s'",
+		n.prettyRead(),
+		n.prettyType(),
+		n.prettyState(),
+		n.Repository.FullName,
+		n.Author.Login,
+		text.RelativeTimeAgo(time.Now(), n.UpdatedAt),
+		n.prettyTitle(),
+	)
+}
+
 func (n *Notification) prettyTitle() string {
 	return strings.ReplaceAll(n.Subject.Title, "\n", " ")
 }
@@ -106,27 +121,53 @@ func (n *Notification) Visible() bool {
 	return !n.Meta.Done && !n.Meta.Hidden
 }
 
-// Render the notifications in a human readable format.
-// If possible, render a table, otherwise render a simple string.
+// Render the notifications in a human readable format, with the columns, or
+// the DefaultColumns if there are none.
+// If possible, render a table, otherwise render a simple string: the columns
+// joined with spaces, or the line format if there are no columns.
+// It returns the header line, which is empty if no column has a header.
 //
 //revive:disable:cognitive-complexity // TODO: simplify.
-func (n Notifications) Render() error {
+func (n Notifications) Render(columns []Column) (string, error) {
 	if len(n) == 0 {
-		return nil
+		return "", nil
+	}
+
+	custom := len(columns) > 0
+	if !custom {
+		columns = defaultColumns()
+	}
+
+	headers := make([]string, len(columns))
+	hasHeader := false
+
+	for i, column := range columns {
+		headers[i] = column.Header
+		hasHeader = hasHeader || column.Header != ""
+	}
+
+	rows := make([][]string, len(n))
+
+	for i, n := range n {
+		rows[i] = make([]string, len(columns))
+
+		for j, column := range columns {
+			rows[i][j] = column.render(n)
+		}
 	}
 
 	// Default to a simple string
-	for _, n := range n {
-		n.rendered = fmt.Sprintf(
-			"%s %s %s %s by %s at %s: '%s'",
-			n.prettyRead(),
-			n.prettyType(),
-			n.prettyState(),
-			n.Repository.FullName,
-			n.Author.Login,
-			text.RelativeTimeAgo(time.Now(), n.UpdatedAt),
-			n.prettyTitle(),
-		)
+	header := ""
+	if hasHeader {
+		header = strings.Join(headers, " ")
+	}
+
+	for i, n := range n {
+		if custom {
+			n.rendered = strings.Join(rows[i], " ")
+		} else {
+			n.rendered = n.line()
+		}
 	}
 
 	// Try to render a table
@@ -136,35 +177,36 @@ func (n Notifications) Render() error {
 
 	w, _, err := t.Size()
 	if err != nil {
-		return fmt.Errorf("failed to get terminal size: %w", err)
+		return header, fmt.Errorf("failed to get terminal size: %w", err)
 	}
 
 	printer := tableprinter.New(&out, t.IsTerminalOutput(), w)
 
-	for _, n := range n {
-		printer.AddField(n.prettyRead())
-		printer.AddField(n.prettyType())
-		printer.AddField(n.prettyState())
-		printer.AddField(n.Repository.FullName)
-		printer.AddField(n.Author.Login)
-		printer.AddField(n.prettyTitle())
-
-		relativeTime := text.RelativeTimeAgo(time.Now(), n.UpdatedAt)
-		if n.LatestCommentor.Login != "" {
-			relativeTime += " by " + n.LatestCommentor.Login
+	if hasHeader {
+		rows = append([][]string{headers}, rows...)
+	}
+
+	for _, row := range rows {
+		for _, field := range row {
+			printer.AddField(field)
 		}
 
-		printer.AddField(relativeTime)
 		printer.EndRow()
 	}
 
 	if err := printer.Render(); err != nil {
-		return fmt.Errorf("failed to render table: %w", err)
+		return header, fmt.Errorf("failed to render table: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+
+	if hasHeader {
+		header, lines = lines[0], lines[1:]
 	}
 
-	for i, l := range strings.Split(strings.TrimRight(out.String(), "\n"), "\n") {
+	for i, l := range lines {
 		n[i].rendered = l
 	}
 
-	return nil
+	return header, nil
 }
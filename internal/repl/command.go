@@ -13,11 +13,29 @@ import (
 )
 
 type Run struct {
+	Name   string
 	Runner actions.Runner
 	Args   []string
 }
 
-var errInvalidCommand = errors.New("invalid command")
+var (
+	errInvalidCommand = errors.New("invalid command")
+	errNoMatch        = errors.New("no notification matches")
+	errRefreshing     = errors.New("a refresh is running, run the command when it's done")
+)
+
+// The REPL's own commands, they take notification identifiers, e.g.
+// `nobe4/gh-not#1` or a pasted URL. See notifications.Notification.Matches.
+const (
+	// commandGoto moves the cursor to the notification.
+	commandGoto = "goto"
+
+	// commandSelect selects the notifications.
+	commandSelect = "select"
+
+	// commandRefresh refreshes the notifications, it takes no identifier.
+	commandRefresh = "refresh"
+)
 
 func (m model) commandAndArgs(value, suggestion string) (string, []string) {
 	if value == suggestion {
@@ -47,11 +65,84 @@ func (m model) acceptCommand() (tea.Model, tea.Cmd) {
 
 	m.command.SetValue("")
 	m.command.Blur()
+
+	switch command {
+	case commandGoto:
+		return m.gotoItem(args)
+	case commandSelect:
+		return m.selectItems(args)
+	case commandRefresh:
+		return m.startRefresh()
+	default:
+	}
+
 	m.showResult = true
 
+	if m.refreshing {
+		return m, m.renderResult(errRefreshing)
+	}
+
 	return m, m.applyCommand(command, args)
 }
 
+// gotoItem moves the cursor to the first visible notification matching the
+// identifier.
+func (m model) gotoItem(refs []string) (tea.Model, tea.Cmd) {
+	if len(refs) != 1 {
+		m.showResult = true
+
+		return m, m.renderResult(fmt.Errorf("%w: %s takes one identifier", errInvalidCommand, commandGoto))
+	}
+
+	for i, e := range m.list.VisibleItems() {
+		if n, ok := e.(item); ok && n.notification.Matches(refs[0]) {
+			m.list.Select(i)
+
+			return m, nil
+		}
+	}
+
+	m.showResult = true
+
+	return m, m.renderResult(fmt.Errorf("%w: %s", errNoMatch, refs[0]))
+}
+
+// selectItems selects the notifications matching the identifiers.
+func (m model) selectItems(refs []string) (tea.Model, tea.Cmd) {
+	if len(refs) == 0 {
+		m.showResult = true
+
+		return m, m.renderResult(fmt.Errorf("%w: %s takes identifiers", errInvalidCommand, commandSelect))
+	}
+
+	cmds := []tea.Cmd{}
+	missing := []string{}
+
+	for _, ref := range refs {
+		found := false
+
+		for _, e := range m.list.Items() {
+			if n, ok := e.(item); ok && n.notification.Matches(ref) {
+				found = true
+				n.selected = true
+				cmds = append(cmds, m.list.SetItem(n.index, n))
+			}
+		}
+
+		if !found {
+			missing = append(missing, ref)
+		}
+	}
+
+	if len(missing) > 0 {
+		m.showResult = true
+
+		cmds = append(cmds, m.renderResult(fmt.Errorf("%w: %s", errNoMatch, strings.Join(missing, ", "))))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
 func (m model) cancelCommand() (tea.Model, tea.Cmd) {
 	slog.Debug("cancelCommand")
 
@@ -93,6 +184,7 @@ func (msg ApplyCommandMsg) apply(m model) (tea.Model, tea.Cmd) {
 
 	m.resultStrings = []string{}
 	m.currentRun = Run{
+		Name:   msg.Command,
 		Runner: runner,
 		Args:   msg.Args,
 	}
@@ -134,12 +226,17 @@ func (m model) applyNext() tea.Cmd {
 		var message string
 
 		out := &strings.Builder{}
-		if err := m.currentRun.Runner.Run(current.notification, m.currentRun.Args, out); err != nil {
+		before := current.notification.Status()
+
+		err := m.currentRun.Runner.Run(current.notification, m.currentRun.Args, out)
+		if err != nil {
 			message = fmt.Sprintf("Error for '%s': %s", current.notification.Subject.Title, err.Error())
 		} else {
 			message = out.String()
 		}
 
+		current.notification.RecordAction(before, m.clock(), m.currentRun.Name, m.currentRun.Args, "", err)
+
 		return AppliedCommandMsg{Message: message}
 	}
 }
@@ -0,0 +1,74 @@
+package repl
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+type readRunner struct{}
+
+func (readRunner) Run(n *notifications.Notification, _ []string, _ io.Writer) error {
+	n.Unread = false
+
+	return nil
+}
+
+func TestApplyNextHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1, 0)
+	n := &notifications.Notification{ID: "0", Unread: true}
+
+	m := newTestModel(notifications.Notifications{n}, nil)
+	m.now = func() time.Time { return now }
+	m.currentRun = Run{Name: "read", Runner: readRunner{}}
+	m.processQueue = []item{{notification: n}}
+
+	if _, ok := m.applyNext()().(AppliedCommandMsg); !ok {
+		t.Fatal("want the command applied")
+	}
+
+	want := []notifications.Event{
+		{Time: now, Type: notifications.EventAction, Action: "read"},
+		{Time: now, Type: notifications.EventRead},
+	}
+
+	if !reflect.DeepEqual(n.Meta.History, want) {
+		t.Errorf("want %#v, got %#v", want, n.Meta.History)
+	}
+}
+
+func TestCleanListRender(t *testing.T) {
+	t.Parallel()
+
+	n := testNotifications("0", "1")
+
+	m := newTestModel(n, nil)
+	m.view = config.View{Columns: []config.Column{{Name: "id"}, {Filter: `.meta.tags | join(",")`}}}
+	m.render()
+
+	if got := n[0].String(); strings.Contains(got, "acted") {
+		t.Fatalf("want no tag yet, got %q", got)
+	}
+
+	// As the actions do.
+	n[0].Meta.Tags = []string{"acted"}
+	n[1].Meta.Hidden = true
+
+	next, _ := CleanListMsg{}.apply(m)
+	m, _ = next.(model)
+
+	if got := n[0].String(); !strings.Contains(got, "acted") {
+		t.Errorf("want the line rendered again, got %q", got)
+	}
+
+	if got, want := itemIDs(m.list.Items()), []string{"0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want items %v, got %v", want, got)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nobe4/gh-not/internal/notifications"
 )
 
 func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -18,6 +20,12 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case m.command.Focused():
 		return m.handleCommand(msg)
 
+	case m.jqInput.Focused():
+		return m.handleJq(msg)
+
+	case m.ruleInput.Focused():
+		return m.handleRule(msg)
+
 	case m.list.FilterState() == list.Filtering:
 		return m.handleFiltering(msg)
 
@@ -29,9 +37,46 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 type CleanListMsg struct{}
 
 func (CleanListMsg) apply(m model) (tea.Model, tea.Cmd) {
-	items := []list.Item{}
+	items := visible(m.list.Items())
+	m.all = visible(m.syncAll())
+
+	m.render()
+
+	return m, tea.Sequence(
+		// SetItems is needed here because the list might have less items now.
+		m.list.SetItems(items),
+		m.setIndexes(),
+	)
+}
+
+// render renders the notifications' lines again, e.g. after the commands
+// changed them. The columns are new for each render, so that the jq ones see
+// the changes.
+func (m model) render() {
+	columns, err := m.view.RenderColumns()
+	if err != nil {
+		slog.Warn("failed to get the columns", "err", err)
+
+		return
+	}
+
+	n := make(notifications.Notifications, 0, len(m.all))
+
+	for _, e := range m.all {
+		if i, ok := e.(item); ok {
+			n = append(n, i.notification)
+		}
+	}
+
+	if _, err := n.Render(columns); err != nil {
+		slog.Warn("failed to render the notifications", "err", err)
+	}
+}
+
+func visible(items []list.Item) []list.Item {
+	kept := []list.Item{}
 
-	for _, e := range m.list.Items() {
+	for _, e := range items {
 		i, ok := e.(item)
 
 		if !ok {
@@ -42,14 +87,10 @@ func (CleanListMsg) apply(m model) (tea.Model, tea.Cmd) {
 			continue
 		}
 
-		items = append(items, e)
+		kept = append(kept, e)
 	}
 
-	return m, tea.Sequence(
-		// SetItems is needed here because the list might have less items now.
-		m.list.SetItems(items),
-		m.setIndexes(),
-	)
+	return kept
 }
 
 func (m model) handleCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -125,11 +166,32 @@ func (m model) handleBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case key.Matches(msg, m.keymap.Preview):
+		m.showPreview = !m.showPreview
+		slog.Debug("toggle preview", "showPreview", m.showPreview)
+
+		return m.layout(), nil
+
+	case key.Matches(msg, m.keymap.Refresh):
+		return m.startRefresh()
+
 	case key.Matches(msg, m.keymap.CommandMode):
 		slog.Debug("focus command")
 
 		return m, m.command.Focus()
 
+	case key.Matches(msg, m.keymap.JqMode):
+		slog.Debug("focus jq")
+
+		m.jqHistoryIndex = len(m.jqHistory)
+
+		return m, m.jqInput.Focus()
+
+	case key.Matches(msg, m.keymap.RuleMode):
+		slog.Debug("focus rule")
+
+		return m, m.ruleInput.Focus()
+
 	default:
 	}
 
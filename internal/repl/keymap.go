@@ -14,29 +14,56 @@ type Keymap struct {
 	None   key.Binding
 	Open   key.Binding
 
+	Preview key.Binding
+	Refresh key.Binding
+
 	CommandMode key.Binding
 
 	CommandAccept key.Binding
 	CommandCancel key.Binding
+
+	JqMode   key.Binding
+	RuleMode key.Binding
+
+	JqAccept        key.Binding
+	JqCancel        key.Binding
+	HistoryPrevious key.Binding
+	HistoryNext     key.Binding
+
+	RuleAccept key.Binding
+	RuleCancel key.Binding
 }
 
 func (k Keymap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Toggle, k.All, k.None},
-		{k.CommandMode, k.Open},
+		{k.CommandMode, k.Open, k.Preview, k.Refresh},
 		{k.CommandAccept, k.CommandCancel},
+		{k.JqMode, k.RuleMode},
+		{k.JqAccept, k.JqCancel, k.HistoryPrevious, k.HistoryNext},
+		{k.RuleAccept, k.RuleCancel},
 	}
 }
 
 func (m model) initKeymap(keymap config.Keymap) model {
 	m.keymap = Keymap{
-		Toggle:        keymap.Binding("normal", "toggle selected"),
-		All:           keymap.Binding("normal", "select all"),
-		None:          keymap.Binding("normal", "select none"),
-		Open:          keymap.Binding("normal", "open in browser"),
-		CommandMode:   keymap.Binding("normal", "command mode"),
-		CommandAccept: keymap.Binding("command", "command accept"),
-		CommandCancel: keymap.Binding("command", "command cancel"),
+		Toggle:          keymap.Binding("normal", "toggle selected"),
+		All:             keymap.Binding("normal", "select all"),
+		None:            keymap.Binding("normal", "select none"),
+		Open:            keymap.Binding("normal", "open in browser"),
+		Preview:         keymap.Binding("normal", "toggle preview"),
+		Refresh:         keymap.Binding("normal", "refresh"),
+		CommandMode:     keymap.Binding("normal", "command mode"),
+		CommandAccept:   keymap.Binding("command", "command accept"),
+		CommandCancel:   keymap.Binding("command", "command cancel"),
+		JqMode:          keymap.Binding("normal", "jq filter mode"),
+		RuleMode:        keymap.Binding("normal", "rule filter mode"),
+		JqAccept:        keymap.Binding("jq", "jq accept"),
+		JqCancel:        keymap.Binding("jq", "jq cancel"),
+		HistoryPrevious: keymap.Binding("jq", "history previous"),
+		HistoryNext:     keymap.Binding("jq", "history next"),
+		RuleAccept:      keymap.Binding("rule", "rule accept"),
+		RuleCancel:      keymap.Binding("rule", "rule cancel"),
 	}
 
 	m.list.KeyMap = list.KeyMap{
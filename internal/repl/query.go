@@ -0,0 +1,271 @@
+//nolint:ireturn // This is how bubbleteam works.
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/jq"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+// jqHistorySize is the number of jq expressions remembered in a session.
+const jqHistorySize = 20
+
+var errUnknownRule = errors.New("unknown rule")
+
+type filterFunc func(notifications.Notifications) (notifications.Notifications, error)
+
+func (m model) handleJq(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keymap.JqAccept):
+		return m.acceptJq()
+
+	case key.Matches(msg, m.keymap.JqCancel):
+		m.jqInput.SetValue("")
+		m.jqInput.Blur()
+
+		return m, nil
+
+	case key.Matches(msg, m.keymap.HistoryPrevious):
+		return m.browseHistory(-1), nil
+
+	case key.Matches(msg, m.keymap.HistoryNext):
+		return m.browseHistory(1), nil
+
+	default:
+	}
+
+	var cmd tea.Cmd
+
+	m.jqInput, cmd = m.jqInput.Update(msg)
+
+	return m, cmd
+}
+
+func (m model) acceptJq() (tea.Model, tea.Cmd) {
+	filter := strings.TrimSpace(m.jqInput.Value())
+
+	slog.Debug("acceptJq", "filter", filter)
+
+	m.jqInput.SetValue("")
+	m.jqInput.Blur()
+
+	if filter == "" {
+		return m.resetItems()
+	}
+
+	m = m.remember(filter)
+
+	return m.filterItems("\\"+filter, func(n notifications.Notifications) (notifications.Notifications, error) {
+		return jq.Filter(filter, n)
+	})
+}
+
+// remember adds the filter to the history, newest last.
+func (m model) remember(filter string) model {
+	m.jqHistory = append(m.jqHistory, filter)
+
+	if l := len(m.jqHistory); l >= 2 && m.jqHistory[l-2] == filter {
+		m.jqHistory = m.jqHistory[:l-1]
+	}
+
+	if l := len(m.jqHistory); l > jqHistorySize {
+		m.jqHistory = m.jqHistory[l-jqHistorySize:]
+	}
+
+	m.jqHistoryIndex = len(m.jqHistory)
+
+	return m
+}
+
+// browseHistory moves through the history by offset. Going past the newest
+// expression clears the input.
+func (m model) browseHistory(offset int) model {
+	m.jqHistoryIndex = max(0, min(len(m.jqHistory), m.jqHistoryIndex+offset))
+
+	if m.jqHistoryIndex == len(m.jqHistory) {
+		m.jqInput.SetValue("")
+	} else {
+		m.jqInput.SetValue(m.jqHistory[m.jqHistoryIndex])
+	}
+
+	m.jqInput.CursorEnd()
+
+	return m
+}
+
+func (m model) handleRule(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keymap.RuleAccept):
+		return m.acceptRule()
+
+	case key.Matches(msg, m.keymap.RuleCancel):
+		m.ruleInput.SetValue("")
+		m.ruleInput.Blur()
+
+		return m, nil
+
+	default:
+	}
+
+	var cmd tea.Cmd
+
+	m.ruleInput, cmd = m.ruleInput.Update(msg)
+
+	return m, cmd
+}
+
+func (m model) acceptRule() (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(m.ruleInput.Value())
+
+	// Prefer the typed name when it's a rule, e.g. `work` over `work-prs`.
+	if _, exact := m.rule(name); !exact {
+		if suggestion := m.ruleInput.CurrentSuggestion(); name != "" && suggestion != "" {
+			name = suggestion
+		}
+	}
+
+	slog.Debug("acceptRule", "name", name)
+
+	m.ruleInput.SetValue("")
+	m.ruleInput.Blur()
+
+	if name == "" {
+		return m.resetItems()
+	}
+
+	rule, ok := m.rule(name)
+	if !ok {
+		m.showResult = true
+
+		return m, m.renderResult(fmt.Errorf("%w: %q", errUnknownRule, name))
+	}
+
+	return m.filterItems("rule "+name, rule.Filter)
+}
+
+// rule returns the first rule named name.
+func (m model) rule(name string) (config.Rule, bool) {
+	for _, rule := range m.rules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+
+	return config.Rule{}, false
+}
+
+// ruleNames returns the names of the named rules, for suggestions.
+func ruleNames(rules []config.Rule) []string {
+	names := []string{}
+
+	for _, rule := range rules {
+		if rule.Name != "" {
+			names = append(names, rule.Name)
+		}
+	}
+
+	return names
+}
+
+// itemFilter is a jq or rule filter applied to the list.
+type itemFilter struct {
+	description string
+	filter      filterFunc
+}
+
+// filterItems narrows the list to the items whose notification passes the
+// filter. Filters stack, each one applies to the current items.
+func (m model) filterItems(description string, filter filterFunc) (tea.Model, tea.Cmd) {
+	m.all = m.syncAll()
+
+	items, err := filterList(m.list.Items(), filter)
+	if err != nil {
+		m.showResult = true
+
+		return m, m.renderResult(err)
+	}
+
+	m.filters = append(m.filters, itemFilter{description: description, filter: filter})
+
+	return m, tea.Sequence(
+		m.list.SetItems(items),
+		m.setIndexes(),
+	)
+}
+
+// filterList keeps the items whose notification passes the filter.
+func filterList(items []list.Item, filter filterFunc) ([]list.Item, error) {
+	n := make(notifications.Notifications, 0, len(items))
+
+	for _, e := range items {
+		if i, ok := e.(item); ok {
+			n = append(n, i.notification)
+		}
+	}
+
+	kept, err := filter(n)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[*notifications.Notification]bool, len(kept))
+	for _, notification := range kept {
+		keep[notification] = true
+	}
+
+	filtered := []list.Item{}
+
+	for _, e := range items {
+		if i, ok := e.(item); ok && keep[i.notification] {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return filtered, nil
+}
+
+// resetItems removes all the jq and rule filters.
+func (m model) resetItems() (tea.Model, tea.Cmd) {
+	m.all = m.syncAll()
+	m.filters = nil
+
+	return m, tea.Sequence(
+		m.list.SetItems(m.all),
+		m.setIndexes(),
+	)
+}
+
+// syncAll returns all the items, with the state of the ones currently in the
+// list, e.g. their selection.
+func (m model) syncAll() []list.Item {
+	current := map[*notifications.Notification]list.Item{}
+
+	for _, e := range m.list.Items() {
+		if i, ok := e.(item); ok {
+			current[i.notification] = e
+		}
+	}
+
+	all := make([]list.Item, 0, len(m.all))
+
+	for _, e := range m.all {
+		if i, ok := e.(item); ok {
+			if c, ok := current[i.notification]; ok {
+				e = c
+			}
+		}
+
+		all = append(all, e)
+	}
+
+	return all
+}
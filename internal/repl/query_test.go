@@ -0,0 +1,359 @@
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+var errFilter = errors.New("filter error")
+
+func newTestModel(n notifications.Notifications, rules []config.Rule) model {
+	items := make([]list.Item, 0, len(n))
+	for _, notification := range n {
+		items = append(items, item{notification: notification})
+	}
+
+	m := model{
+		list:      list.New(items, itemDelegate{}, 0, 0),
+		command:   textinput.New(),
+		jqInput:   textinput.New(),
+		ruleInput: textinput.New(),
+		all:       items,
+		rules:     rules,
+	}
+
+	return m.initView()
+}
+
+func testNotifications(ids ...string) notifications.Notifications {
+	n := notifications.Notifications{}
+	for _, id := range ids {
+		n = append(n, &notifications.Notification{ID: id})
+	}
+
+	return n
+}
+
+func itemIDs(items []list.Item) []string {
+	ids := []string{}
+
+	for _, e := range items {
+		if i, ok := e.(item); ok {
+			ids = append(ids, i.notification.ID)
+		}
+	}
+
+	return ids
+}
+
+// keepIDs returns a filter that keeps the notifications with the given IDs.
+func keepIDs(ids ...string) filterFunc {
+	return func(n notifications.Notifications) (notifications.Notifications, error) {
+		kept := notifications.Notifications{}
+
+		for _, notification := range n {
+			for _, id := range ids {
+				if notification.ID == id {
+					kept = append(kept, notification)
+				}
+			}
+		}
+
+		return kept, nil
+	}
+}
+
+func TestRemember(t *testing.T) {
+	t.Parallel()
+
+	many := []string{}
+	for i := range jqHistorySize + 5 {
+		many = append(many, fmt.Sprintf(".%d", i))
+	}
+
+	tests := []struct {
+		name    string
+		history []string
+		filter  string
+		want    []string
+	}{
+		{
+			name:   "empty",
+			filter: ".a",
+			want:   []string{".a"},
+		},
+		{
+			name:    "newest last",
+			history: []string{".a"},
+			filter:  ".b",
+			want:    []string{".a", ".b"},
+		},
+		{
+			name:    "repeated",
+			history: []string{".a", ".b"},
+			filter:  ".b",
+			want:    []string{".a", ".b"},
+		},
+		{
+			name:    "repeated earlier",
+			history: []string{".a", ".b"},
+			filter:  ".a",
+			want:    []string{".a", ".b", ".a"},
+		},
+		{
+			name:    "capped",
+			history: many[:jqHistorySize],
+			filter:  many[jqHistorySize],
+			want:    many[1 : jqHistorySize+1],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestModel(nil, nil)
+			m.jqHistory = append([]string{}, test.history...)
+
+			m = m.remember(test.filter)
+
+			if !reflect.DeepEqual(m.jqHistory, test.want) {
+				t.Errorf("want %v, got %v", test.want, m.jqHistory)
+			}
+
+			if m.jqHistoryIndex != len(test.want) {
+				t.Errorf("want the index past the newest, %d, got %d", len(test.want), m.jqHistoryIndex)
+			}
+		})
+	}
+}
+
+func TestBrowseHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		history   []string
+		index     int
+		offsets   []int
+		wantIndex int
+		wantValue string
+	}{
+		{
+			name:      "empty",
+			offsets:   []int{-1},
+			wantIndex: 0,
+			wantValue: "",
+		},
+		{
+			name:      "previous",
+			history:   []string{".a", ".b"},
+			index:     2,
+			offsets:   []int{-1},
+			wantIndex: 1,
+			wantValue: ".b",
+		},
+		{
+			name:      "clamped at the oldest",
+			history:   []string{".a", ".b"},
+			index:     2,
+			offsets:   []int{-1, -1, -1},
+			wantIndex: 0,
+			wantValue: ".a",
+		},
+		{
+			name:      "back to the newest",
+			history:   []string{".a", ".b"},
+			index:     0,
+			offsets:   []int{1},
+			wantIndex: 1,
+			wantValue: ".b",
+		},
+		{
+			name:      "past the newest clears",
+			history:   []string{".a", ".b"},
+			index:     1,
+			offsets:   []int{1},
+			wantIndex: 2,
+			wantValue: "",
+		},
+		{
+			name:      "clamped past the newest",
+			history:   []string{".a", ".b"},
+			index:     2,
+			offsets:   []int{1, 1},
+			wantIndex: 2,
+			wantValue: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestModel(nil, nil)
+			m.jqHistory = test.history
+			m.jqHistoryIndex = test.index
+			m.jqInput.SetValue("typed")
+
+			for _, offset := range test.offsets {
+				m = m.browseHistory(offset)
+			}
+
+			if m.jqHistoryIndex != test.wantIndex {
+				t.Errorf("want index %d, got %d", test.wantIndex, m.jqHistoryIndex)
+			}
+
+			if got := m.jqInput.Value(); got != test.wantValue {
+				t.Errorf("want value %q, got %q", test.wantValue, got)
+			}
+		})
+	}
+}
+
+func TestFilterList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters []filterFunc
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "no filter",
+			want: []string{"0", "1", "2", "3"},
+		},
+		{
+			name:    "one filter",
+			filters: []filterFunc{keepIDs("1", "2")},
+			want:    []string{"1", "2"},
+		},
+		{
+			name:    "stacked filters",
+			filters: []filterFunc{keepIDs("1", "2", "3"), keepIDs("0", "2", "3"), keepIDs("3")},
+			want:    []string{"3"},
+		},
+		{
+			name:    "keeps the order",
+			filters: []filterFunc{keepIDs("3", "0")},
+			want:    []string{"0", "3"},
+		},
+		{
+			name:    "nothing left",
+			filters: []filterFunc{keepIDs("1"), keepIDs("2")},
+			want:    []string{},
+		},
+		{
+			name: "error",
+			filters: []filterFunc{func(notifications.Notifications) (notifications.Notifications, error) {
+				return nil, errFilter
+			}},
+			wantErr: errFilter,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			items := newTestModel(testNotifications("0", "1", "2", "3"), nil).all
+
+			var err error
+
+			for _, filter := range test.filters {
+				if items, err = filterList(items, filter); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("want error %v, got %v", test.wantErr, err)
+			}
+
+			if test.wantErr != nil {
+				return
+			}
+
+			if got := itemIDs(items); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFilterItems(t *testing.T) {
+	t.Parallel()
+
+	m := newTestModel(testNotifications("0", "1", "2", "3"), nil)
+
+	next, _ := m.filterItems("first", keepIDs("1", "2", "3"))
+	m, _ = next.(model)
+
+	next, _ = m.filterItems("second", keepIDs("0", "2", "3"))
+	m, _ = next.(model)
+
+	if got, want := itemIDs(m.list.Items()), []string{"2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got := len(m.filters); got != 2 {
+		t.Errorf("want 2 filters, got %d", got)
+	}
+
+	next, _ = m.resetItems()
+	m, _ = next.(model)
+
+	if got, want := itemIDs(m.list.Items()), []string{"0", "1", "2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if len(m.filters) != 0 {
+		t.Errorf("want no filters, got %d", len(m.filters))
+	}
+}
+
+func TestAcceptRule(t *testing.T) {
+	t.Parallel()
+
+	rules := []config.Rule{{Name: "work-prs"}, {Name: "work"}, {Name: "personal"}}
+
+	tests := []struct {
+		typed string
+		want  string
+	}{
+		{typed: "work", want: "rule work"},
+		{typed: "work-", want: "rule work-prs"},
+		{typed: "pers", want: "rule personal"},
+		{typed: " personal ", want: "rule personal"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typed, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestModel(testNotifications("0"), rules)
+			m.ruleInput.SetValue(test.typed)
+			m.ruleInput.SetSuggestions(ruleNames(rules))
+
+			next, _ := m.acceptRule()
+			m, _ = next.(model)
+
+			if len(m.filters) != 1 {
+				t.Fatalf("want 1 filter, got %d", len(m.filters))
+			}
+
+			if got := m.filters[0].description; got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+//nolint:ireturn // This is how bubbleteam works.
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+// Refresher refreshes a copy of the notifications, e.g. syncs it and applies
+// the rules, and returns the ones to list.
+// It runs in the background, so it must not write to STDOUT nor change the
+// listed notifications. The REPL calls keep before listing the refreshed ones,
+// e.g. to keep the copy. It can return notifications to list along with an
+// error, e.g. when a rule failed after the others ran.
+type Refresher func() (n notifications.Notifications, keep func(), err error)
+
+var (
+	errNoRefresher = errors.New("refreshing is not available")
+	errBusy        = errors.New("a command is running, refresh when it's done")
+)
+
+type RefreshedMsg struct {
+	notifications notifications.Notifications
+	keep          func()
+	err           error
+}
+
+type AutoRefreshMsg struct{}
+
+// startRefresh runs the refresher in the background, showing a spinner until
+// it's done.
+func (m model) startRefresh() (tea.Model, tea.Cmd) {
+	if m.refresher == nil {
+		m.showResult = true
+
+		return m, m.renderResult(errNoRefresher)
+	}
+
+	if m.refreshing {
+		return m, nil
+	}
+
+	if len(m.processQueue) > 0 {
+		m.showResult = true
+
+		return m, m.renderResult(errBusy)
+	}
+
+	slog.Debug("start refresh")
+
+	m.refreshing = true
+	refresher := m.refresher
+
+	return m, tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			n, keep, err := refresher()
+
+			return RefreshedMsg{notifications: n, keep: keep, err: err}
+		},
+	)
+}
+
+// scheduleRefresh sends an AutoRefreshMsg after the refresh interval, if any.
+func (m model) scheduleRefresh() tea.Cmd {
+	if m.refreshInterval <= 0 || m.refresher == nil {
+		return nil
+	}
+
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return AutoRefreshMsg{}
+	})
+}
+
+func (AutoRefreshMsg) apply(m model) (tea.Model, tea.Cmd) {
+	next := m.scheduleRefresh()
+
+	// Don't interrupt a running command or refresh, try again later.
+	if m.refreshing || len(m.processQueue) > 0 {
+		return m, next
+	}
+
+	model, cmd := m.startRefresh()
+
+	return model, tea.Batch(cmd, next)
+}
+
+func (msg RefreshedMsg) apply(m model) (tea.Model, tea.Cmd) {
+	slog.Debug("refreshed", "count", len(msg.notifications), "error", msg.err)
+
+	m.refreshing = false
+
+	var result tea.Cmd
+
+	if msg.err != nil {
+		m.showResult = true
+		result = m.renderResult(fmt.Errorf("failed to refresh: %w", msg.err))
+	}
+
+	if msg.keep == nil {
+		return m, result
+	}
+
+	// This runs on the UI's goroutine, so the refreshed notifications replace
+	// the listed ones at once.
+	msg.keep()
+
+	model, cmd := m.merge(msg.notifications)
+
+	return model, tea.Batch(cmd, result)
+}
+
+// merge replaces the items with the refreshed notifications. It keeps the
+// selection, the cursor and the jq and rule filters.
+// The notifications are matched by ID, as the refresh replaces the updated
+// ones.
+func (m model) merge(n notifications.Notifications) (tea.Model, tea.Cmd) {
+	selected := map[string]bool{}
+
+	for _, e := range m.syncAll() {
+		if i, ok := e.(item); ok && i.selected {
+			selected[i.notification.ID] = true
+		}
+	}
+
+	cursor := ""
+	if i, ok := m.list.SelectedItem().(item); ok {
+		cursor = i.notification.ID
+	}
+
+	m.all = make([]list.Item, 0, len(n))
+	for _, notification := range n {
+		m.all = append(m.all, item{notification: notification, selected: selected[notification.ID]})
+	}
+
+	items := m.all
+
+	for _, f := range m.filters {
+		var err error
+
+		if items, err = filterList(items, f.filter); err != nil {
+			slog.Warn("failed to filter the refreshed items", "filter", f.description, "error", err)
+
+			items, m.filters = m.all, nil
+
+			break
+		}
+	}
+
+	cmd := m.list.SetItems(items)
+
+	for index, e := range m.list.VisibleItems() {
+		if i, ok := e.(item); ok && i.notification.ID == cursor {
+			m.list.Select(index)
+
+			break
+		}
+	}
+
+	if last := len(m.list.VisibleItems()) - 1; m.list.Index() > last {
+		m.list.Select(max(0, last))
+	}
+
+	return m, tea.Sequence(cmd, m.setIndexes())
+}
@@ -0,0 +1,299 @@
+package repl
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+var errRefresh = errors.New("refresh error")
+
+func selectedIDs(items []list.Item) []string {
+	ids := []string{}
+
+	for _, e := range items {
+		if i, ok := e.(item); ok && i.selected {
+			ids = append(ids, i.notification.ID)
+		}
+	}
+
+	return ids
+}
+
+func cursorID(m model) string {
+	if i, ok := m.list.SelectedItem().(item); ok {
+		return i.notification.ID
+	}
+
+	return ""
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		refreshed    notifications.Notifications
+		filter       filterFunc
+		wantItems    []string
+		wantAll      []string
+		wantSelected []string
+		wantCursor   string
+	}{
+		{
+			name:         "keeps the selection and the cursor",
+			refreshed:    testNotifications("4", "0", "1", "2", "3"),
+			wantItems:    []string{"4", "0", "1", "2", "3"},
+			wantAll:      []string{"4", "0", "1", "2", "3"},
+			wantSelected: []string{"1", "3"},
+			wantCursor:   "2",
+		},
+		{
+			name:         "drops the missing notifications",
+			refreshed:    testNotifications("0", "2"),
+			wantItems:    []string{"0", "2"},
+			wantAll:      []string{"0", "2"},
+			wantSelected: []string{},
+			wantCursor:   "2",
+		},
+		{
+			name:         "moves the cursor back in the list",
+			refreshed:    testNotifications("0", "1"),
+			wantItems:    []string{"0", "1"},
+			wantAll:      []string{"0", "1"},
+			wantSelected: []string{"1"},
+			wantCursor:   "1",
+		},
+		{
+			name:         "reapplies the filters",
+			refreshed:    testNotifications("4", "0", "1", "2", "3"),
+			filter:       keepIDs("1", "2", "4"),
+			wantItems:    []string{"4", "1", "2"},
+			wantAll:      []string{"4", "0", "1", "2", "3"},
+			wantSelected: []string{"1", "3"},
+			wantCursor:   "2",
+		},
+		{
+			name:      "drops the failing filters",
+			refreshed: testNotifications("0", "1"),
+			filter: func(notifications.Notifications) (notifications.Notifications, error) {
+				return nil, errFilter
+			},
+			wantItems:    []string{"0", "1"},
+			wantAll:      []string{"0", "1"},
+			wantSelected: []string{"1"},
+			wantCursor:   "1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestModel(testNotifications("0", "1", "2", "3"), nil)
+			m.list.SetSize(80, 10)
+
+			for index, e := range m.list.Items() {
+				if i, ok := e.(item); ok && (i.notification.ID == "1" || i.notification.ID == "3") {
+					i.selected = true
+					m.list.SetItem(index, i)
+				}
+			}
+
+			if test.filter != nil {
+				// Filter with an error only after the refresh.
+				filter, filtered := test.filter, false
+				wrapped := func(n notifications.Notifications) (notifications.Notifications, error) {
+					if !filtered {
+						filtered = true
+
+						return n, nil
+					}
+
+					return filter(n)
+				}
+
+				next, _ := m.filterItems("test", wrapped)
+				m, _ = next.(model)
+			}
+
+			m.list.Select(2)
+
+			next, _ := m.merge(test.refreshed)
+			m, _ = next.(model)
+
+			if got := itemIDs(m.list.Items()); !reflect.DeepEqual(got, test.wantItems) {
+				t.Errorf("want items %v, got %v", test.wantItems, got)
+			}
+
+			if got := itemIDs(m.all); !reflect.DeepEqual(got, test.wantAll) {
+				t.Errorf("want all %v, got %v", test.wantAll, got)
+			}
+
+			if got := selectedIDs(m.syncAll()); !reflect.DeepEqual(got, test.wantSelected) {
+				t.Errorf("want selected %v, got %v", test.wantSelected, got)
+			}
+
+			if got := cursorID(m); got != test.wantCursor {
+				t.Errorf("want the cursor on %q, got %q", test.wantCursor, got)
+			}
+
+			for _, e := range m.all {
+				if i, ok := e.(item); ok && !slices.Contains(test.refreshed, i.notification) {
+					t.Errorf("want the refreshed notification for %s", i.notification.ID)
+				}
+			}
+		})
+	}
+}
+
+// runCmd runs the command and its batched commands, and returns the first
+// message of type T.
+func runCmd[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	var zero T
+
+	if cmd == nil {
+		return zero, false
+	}
+
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			if msg, ok := runCmd[T](cmd); ok {
+				return msg, true
+			}
+		}
+	}
+
+	return zero, false
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	listed := testNotifications("0", "1")
+	kept := false
+
+	m := newTestModel(listed, nil)
+	m.list.SetSize(80, 10)
+	m.refresher = func() (notifications.Notifications, func(), error) {
+		// Change a copy, as the listed notifications are in use.
+		refreshed := listed.Clone()
+		for _, n := range refreshed {
+			n.Subject.Title = "refreshed"
+			n.Meta.Tags = append(n.Meta.Tags, "refreshed")
+		}
+
+		return refreshed, func() { kept = true }, nil
+	}
+
+	next, cmd := m.startRefresh()
+	m, _ = next.(model)
+
+	if !m.refreshing {
+		t.Fatal("want the model to be refreshing")
+	}
+
+	done := make(chan RefreshedMsg)
+
+	go func() {
+		msg, _ := runCmd[RefreshedMsg](cmd)
+		done <- msg
+	}()
+
+	// The UI keeps reading the listed notifications during the refresh.
+	for _, e := range m.list.Items() {
+		if i, ok := e.(item); ok {
+			_ = i.notification.Subject.Title
+			_ = i.notification.Meta.Tags
+		}
+	}
+
+	msg := <-done
+
+	if kept {
+		t.Fatal("want the copy kept on the UI's goroutine only")
+	}
+
+	next, _ = m.Update(msg)
+	m, _ = next.(model)
+
+	if !kept {
+		t.Error("want the copy kept")
+	}
+
+	if m.refreshing {
+		t.Error("want the refresh done")
+	}
+
+	for _, e := range m.list.Items() {
+		if i, ok := e.(item); ok && i.notification.Subject.Title != "refreshed" {
+			t.Errorf("want the refreshed notification, got %+v", i.notification)
+		}
+	}
+
+	for _, n := range listed {
+		if n.Subject.Title != "" || len(n.Meta.Tags) != 0 {
+			t.Errorf("want the listed notification unchanged, got %+v", n)
+		}
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		refreshed notifications.Notifications
+		keep      bool
+		wantItems []string
+	}{
+		{
+			name:      "nothing to list",
+			wantItems: []string{"0", "1"},
+		},
+		{
+			name:      "partial refresh",
+			refreshed: testNotifications("1", "2"),
+			keep:      true,
+			wantItems: []string{"1", "2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newTestModel(testNotifications("0", "1"), nil)
+			msg := RefreshedMsg{notifications: test.refreshed, err: errRefresh}
+
+			kept := false
+			if test.keep {
+				msg.keep = func() { kept = true }
+			}
+
+			next, _ := msg.apply(m)
+			m, _ = next.(model)
+
+			if !m.showResult {
+				t.Error("want the error shown")
+			}
+
+			if kept != test.keep {
+				t.Errorf("want kept %v, got %v", test.keep, kept)
+			}
+
+			if got := itemIDs(m.list.Items()); !reflect.DeepEqual(got, test.wantItems) {
+				t.Errorf("want items %v, got %v", test.wantItems, got)
+			}
+		})
+	}
+}
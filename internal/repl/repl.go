@@ -4,8 +4,10 @@ package repl
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,22 +20,59 @@ import (
 type model struct {
 	keymap     Keymap
 	actions    actions.Map
+	rules      []config.Rule
 	currentRun Run
 
+	// view renders the notifications' lines again after the commands.
+	view config.View
+
 	showHelp bool
 	list     list.Model
 	command  textinput.Model
 
+	// jqInput and ruleInput narrow the list with a jq filter or a named
+	// rule's filters, all keeps every item to restore them.
+	jqInput        textinput.Model
+	ruleInput      textinput.Model
+	jqHistory      []string
+	jqHistoryIndex int
+	filters        []itemFilter
+	all            []list.Item
+
+	// refresher syncs the notifications in the background, every
+	// refreshInterval if it's set.
+	refresher       Refresher
+	refreshInterval time.Duration
+	refreshing      bool
+	spinner         spinner.Model
+
+	// now returns the current time, used for the history.
+	// Defaults to time.Now.
+	now func() time.Time
+
 	result        viewport.Model
 	resultStrings []string
 
 	ready        bool
 	showResult   bool
+	showPreview  bool
 	processQueue []item
 	maxHeight    int
+
+	// height and width are the space available, within maxHeight.
+	height int
+	width  int
 }
 
-func Init(n notifications.Notifications, a actions.Map, keymap config.Keymap, view config.View) error {
+func Init(
+	n notifications.Notifications,
+	a actions.Map,
+	rules []config.Rule,
+	keymap config.Keymap,
+	view config.View,
+	refresher Refresher,
+	now func() time.Time,
+) error {
 	items := make([]list.Item, 0, len(n))
 	for _, notification := range n {
 		items = append(items, item{notification: notification})
@@ -42,9 +81,19 @@ func Init(n notifications.Notifications, a actions.Map, keymap config.Keymap, vi
 	m := model{
 		list:      list.New(items, itemDelegate{}, 0, 0),
 		command:   textinput.New(),
+		jqInput:   textinput.New(),
+		ruleInput: textinput.New(),
+		all:       items,
 		actions:   a,
+		rules:     rules,
 		result:    viewport.New(0, 0),
+		view:      view,
 		maxHeight: view.Height,
+		refresher: refresher,
+		now:       now,
+		spinner:   spinner.New(spinner.WithSpinner(spinner.Line)),
+
+		refreshInterval: time.Duration(view.RefreshIntervalInMinutes) * time.Minute,
 	}
 
 	m.list.SetItems(items)
@@ -58,8 +107,16 @@ func Init(n notifications.Notifications, a actions.Map, keymap config.Keymap, vi
 	return nil
 }
 
+func (m model) clock() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+
+	return m.now()
+}
+
 func (m model) Init() tea.Cmd {
-	return m.setIndexes()
+	return tea.Batch(m.setIndexes(), m.scheduleRefresh())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -81,6 +138,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CleanListMsg:
 		return msg.apply(m)
 
+	case RefreshedMsg:
+		return msg.apply(m)
+
+	case AutoRefreshMsg:
+		return msg.apply(m)
+
+	case spinner.TickMsg:
+		if !m.refreshing {
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+
+		m.spinner, cmd = m.spinner.Update(msg)
+
+		return m, cmd
+
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
 
@@ -4,6 +4,7 @@ package repl
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/paginator"
@@ -43,7 +44,7 @@ func (m model) initView() model {
 	m.command.PromptStyle = noStyle
 	m.command.Placeholder = "command"
 
-	suggestions := make([]string, 0, len(m.actions))
+	suggestions := []string{commandGoto, commandSelect, commandRefresh}
 	for k := range m.actions {
 		suggestions = append(suggestions, k)
 	}
@@ -51,6 +52,18 @@ func (m model) initView() model {
 	m.command.SetSuggestions(suggestions)
 	m.command.ShowSuggestions = true
 
+	m.jqInput.Prompt = "\\"
+	m.jqInput.Cursor.Style = noStyle
+	m.jqInput.PromptStyle = noStyle
+	m.jqInput.Placeholder = "jq filter"
+
+	m.ruleInput.Prompt = "rule "
+	m.ruleInput.Cursor.Style = noStyle
+	m.ruleInput.PromptStyle = noStyle
+	m.ruleInput.Placeholder = "name"
+	m.ruleInput.SetSuggestions(ruleNames(m.rules))
+	m.ruleInput.ShowSuggestions = true
+
 	return m
 }
 
@@ -60,8 +73,8 @@ func (m model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 
 	slog.Debug("resize", "width", msg.Width, "height", newHeight)
 
-	m.list.SetHeight(newHeight)
-	m.list.SetWidth(msg.Width)
+	m.height = newHeight
+	m.width = msg.Width
 
 	m.result.Height = newHeight
 	m.result.Width = msg.Width
@@ -70,7 +83,44 @@ func (m model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 		m.ready = true
 	}
 
-	return m, nil
+	return m.layout(), nil
+}
+
+// layout splits the height between the list and the preview.
+func (m model) layout() model {
+	listHeight := m.height
+	if m.showPreview {
+		listHeight -= m.previewHeight()
+	}
+
+	m.list.SetHeight(listHeight)
+	m.list.SetWidth(m.width)
+
+	return m
+}
+
+// previewHeight returns the height of the preview, half of the space
+// available.
+func (m model) previewHeight() int {
+	return m.height / 2 //nolint:mnd // Half is explicit enough.
+}
+
+// viewPreview renders the card of the notification under the cursor, cut to
+// the preview's size.
+func (m model) viewPreview() string {
+	card := ""
+	if current, ok := m.list.SelectedItem().(item); ok {
+		card = current.notification.Card()
+	}
+
+	height := m.previewHeight()
+	separator := strings.Repeat("─", m.width)
+
+	return noStyle.
+		MaxWidth(m.width).
+		Height(height).
+		MaxHeight(height).
+		Render(separator + "\n" + card)
 }
 
 type ResultUpdateMsg struct {
@@ -153,19 +203,34 @@ func (m model) View() string {
 
 		statusLine += fmt.Sprintf("%d/%d/%d ", len(m.list.Items()), len(m.list.VisibleItems()), selected)
 
-		if m.command.Focused() {
+		if m.refreshing {
+			statusLine += m.spinner.View() + " "
+		}
+
+		switch {
+		case m.command.Focused():
 			statusLine += m.command.View()
-		} else {
-			if m.list.FilterState() == list.Filtering {
-				statusLine += m.list.FilterInput.View()
-			} else {
-				statusLine += m.list.Help.Styles.ShortDesc.Render("? to toggle help")
+		case m.jqInput.Focused():
+			statusLine += m.jqInput.View()
+		case m.ruleInput.Focused():
+			statusLine += m.ruleInput.View()
+		case m.list.FilterState() == list.Filtering:
+			statusLine += m.list.FilterInput.View()
+		default:
+			for _, f := range m.filters {
+				statusLine += f.description + " "
 			}
+
+			statusLine += m.list.Help.Styles.ShortDesc.Render("? to toggle help")
 		}
 
 		listView := m.list.View()
 
 		content = noStyle.Height(m.list.Height() - 1).Render(listView)
+
+		if m.showPreview {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, m.viewPreview())
+		}
 	}
 
 	sections := []string{
@@ -0,0 +1,109 @@
+package repl
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nobe4/gh-not/internal/config"
+	"github.com/nobe4/gh-not/internal/notifications"
+)
+
+func keyMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func update(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+
+	next, _ := m.Update(msg)
+
+	m, ok := next.(model)
+	if !ok {
+		t.Fatalf("want a model, got %T", next)
+	}
+
+	return m
+}
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	c, err := config.New("/nonexistent/config.yaml", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := notifications.Notifications{
+		{ID: "0", Subject: notifications.Subject{Title: "first title"}},
+		{ID: "1", Subject: notifications.Subject{Title: "second title"}},
+	}
+
+	m := newTestModel(n, nil).initKeymap(c.Data.Keymap)
+	m.maxHeight = 30
+	m = update(t, m, tea.WindowSizeMsg{Width: 60, Height: 21})
+
+	if got := m.list.Height(); got != 20 {
+		t.Fatalf("want the list on the whole height, 20, got %d", got)
+	}
+
+	if strings.Contains(m.View(), "Assignees") {
+		t.Fatal("want no preview yet")
+	}
+
+	m = update(t, m, keyMsg("p"))
+
+	if !m.showPreview {
+		t.Fatal("want the preview shown")
+	}
+
+	if got, want := m.list.Height(), 10; got != want {
+		t.Errorf("want the list on half the height, %d, got %d", want, got)
+	}
+
+	if got, want := m.previewHeight(), 10; got != want {
+		t.Errorf("want the preview on half the height, %d, got %d", want, got)
+	}
+
+	preview := m.viewPreview()
+
+	if got := lipgloss.Height(preview); got != 10 {
+		t.Errorf("want the preview to be 10 lines high, got %d", got)
+	}
+
+	if got := lipgloss.Width(preview); got > 60 {
+		t.Errorf("want the preview to fit in 60 columns, got %d", got)
+	}
+
+	if !strings.Contains(preview, "first title") || strings.Contains(preview, "second title") {
+		t.Errorf("want the first notification's card, got\n%s", preview)
+	}
+
+	view := m.View()
+
+	if !strings.Contains(view, preview) {
+		t.Errorf("want the preview under the list, got\n%s", view)
+	}
+
+	if got := lipgloss.Height(view); got > 21 {
+		t.Errorf("want the view to fit in 21 lines, got %d", got)
+	}
+
+	m = update(t, m, keyMsg("j"))
+
+	if preview := m.viewPreview(); !strings.Contains(preview, "second title") {
+		t.Errorf("want the second notification's card, got\n%s", preview)
+	}
+
+	m = update(t, m, keyMsg("p"))
+
+	if m.showPreview || strings.Contains(m.View(), "Assignees") {
+		t.Error("want the preview hidden")
+	}
+
+	if got := m.list.Height(); got != 20 {
+		t.Errorf("want the list on the whole height again, 20, got %d", got)
+	}
+}
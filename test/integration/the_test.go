@@ -32,7 +32,7 @@ func setup(t *testing.T, conf config) (*manager.Manager, *apipkg.Mock, notificat
 	wantPath := fmt.Sprintf("./%s/want.json", conf.ID)
 	cachePath := fmt.Sprintf("./%s/cache.json", conf.ID)
 
-	c, err := configpkg.New(configPath)
+	c, err := configpkg.New(configPath, "", nil)
 	if err != nil {
 		t.Fatal(err)
 	}